@@ -3,12 +3,15 @@ package main
 import (
 	"fmt"
 	"github.com/ratanvarghese/tqtime"
+	"log"
 	"time"
 )
 
 func main() {
-	t := time.Now()
-	long := tqtime.LongDate(t.Year(), t.YearDay())
-	short := tqtime.ShortDate(t.Year(), t.YearDay())
-	fmt.Printf("%s\t%s\n", long, short)
+	clock, err := tqtime.ClockFromEnv()
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	d := tqtime.Today(clock, time.Local)
+	fmt.Printf("%s\t%s\n", d.LongDate(), d.ShortDate())
 }
@@ -0,0 +1,52 @@
+package tqtime
+
+import "time"
+
+//Span is an interval of time, starting at Start (inclusive) and ending at End (exclusive).
+//
+//The spans returned by YearBounds, MonthBounds and WeekBounds cover whole days from midnight to midnight. Special days are included in a span exactly when they fall between its first and last days: Aldrin Day is part of Hippocrates and of its fourth week, because it falls between 27 and 28 Hippocrates. Armstrong Day comes after 28 Mendel, so it is part of its year but not of Mendel or any week. Moon Landing Day is only part of year 0.
+type Span struct {
+	Start time.Time
+	End   time.Time
+}
+
+//Contains returns true if t is within s.
+func (s Span) Contains(t time.Time) bool {
+	return !t.Before(s.Start) && t.Before(s.End)
+}
+
+//daySpan returns the span from midnight at the start of first to midnight at the end of last, in loc.
+func daySpan(first, last TqDate, loc *time.Location) (Span, error) {
+	start, err := first.Time(loc)
+	if err != nil {
+		return Span{}, err
+	}
+	end, err := last.Time(loc)
+	if err != nil {
+		return Span{}, err
+	}
+	return Span{Start: start, End: end.AddDate(0, 0, 1)}, nil
+}
+
+//YearBounds returns the span of Tranquility year y in loc, including Aldrin Day and Armstrong Day when y has them.
+func YearBounds(y TqYear, loc *time.Location) (Span, error) {
+	return daySpan(y.First(), y.Last(), loc)
+}
+
+//MonthBounds returns the span of month m of Tranquility year y in loc. The span of Hippocrates includes Aldrin Day on leap years, and the span of Mendel does not include Armstrong Day. ErrInvalidDate is returned if m is not a month, or y is year 0.
+func MonthBounds(y TqYear, m TqMonth, loc *time.Location) (Span, error) {
+	first := TqDate{Year: int64(y), Month: m, Day: 1}
+	last := TqDate{Year: int64(y), Month: m, Day: tqMonthLen}
+	return daySpan(first, last, loc)
+}
+
+//WeekBounds returns the span of week w, in range [1,4], of month m of Tranquility year y in loc. Each week runs from Friday to Thursday. The fourth week of Hippocrates includes Aldrin Day on leap years. ErrInvalidDate is returned if w or m are out of range, or y is year 0.
+func WeekBounds(y TqYear, m TqMonth, w int, loc *time.Location) (Span, error) {
+	const weeksPerMonth int = tqMonthLen / 7
+	if w < 1 || w > weeksPerMonth {
+		return Span{}, ErrInvalidDate
+	}
+	first := TqDate{Year: int64(y), Month: m, Day: (w-1)*7 + 1}
+	last := TqDate{Year: int64(y), Month: m, Day: w * 7}
+	return daySpan(first, last, loc)
+}
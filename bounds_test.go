@@ -0,0 +1,112 @@
+package tqtime
+
+import (
+	"testing"
+	"time"
+)
+
+var boundsZone = time.FixedZone("UTC+10", 10*60*60)
+
+//spanDates returns the Tranquility dates of every day in s, and of the days just before and after it.
+func spanDates(s Span) (inside []TqDate, before, after TqDate) {
+	for t := s.Start; t.Before(s.End); t = t.AddDate(0, 0, 1) {
+		inside = append(inside, FromTime(t))
+	}
+	return inside, FromTime(s.Start.AddDate(0, 0, -1)), FromTime(s.End)
+}
+
+func TestMonthBounds(t *testing.T) {
+	for _, y := range []TqYear{-2, -1, 3, 31, 40} {
+		for m := Archimedes; m <= Mendel; m++ {
+			s, err := MonthBounds(y, m, boundsZone)
+			if err != nil {
+				t.Fatalf("MonthBounds(%d,%v) returned %v", y, m, err)
+			}
+			inside, before, after := spanDates(s)
+			aldrin := 0
+			for _, d := range inside {
+				if d.Day == AldrinDay {
+					aldrin++
+				} else if d.Month != m || TqYear(d.Year) != y {
+					t.Errorf("MonthBounds(%d,%v) contains %s", y, m, d.ShortDate())
+				}
+			}
+			if before.Month == m || after.Month == m {
+				t.Errorf("MonthBounds(%d,%v) misses days of the month", y, m)
+			}
+			expectAldrin := m == Hippocrates && y.IsLeap()
+			if (aldrin == 1) != expectAldrin {
+				t.Errorf("MonthBounds(%d,%v) contains %d Aldrin Days", y, m, aldrin)
+			}
+			if len(inside) != tqMonthLen+aldrin {
+				t.Errorf("MonthBounds(%d,%v) contains %d days", y, m, len(inside))
+			}
+		}
+	}
+}
+
+func TestWeekBounds(t *testing.T) {
+	for m := Archimedes; m <= Mendel; m++ {
+		for w := 1; w <= 4; w++ {
+			s, err := WeekBounds(31, m, w, boundsZone)
+			if err != nil {
+				t.Fatalf("WeekBounds(31,%v,%d) returned %v", m, w, err)
+			}
+			inside, _, _ := spanDates(s)
+			if inside[0].Weekday() != Friday || inside[len(inside)-1].Weekday() != Thursday {
+				t.Errorf("WeekBounds(31,%v,%d) runs from %s to %s", m, w, inside[0].LongDate(), inside[len(inside)-1].LongDate())
+			}
+			expected := 7
+			if m == Hippocrates && w == 4 {
+				expected = 8
+			}
+			if len(inside) != expected {
+				t.Errorf("WeekBounds(31,%v,%d) contains %d days", m, w, len(inside))
+			}
+		}
+	}
+	if _, err := WeekBounds(31, Archimedes, 5, boundsZone); err != ErrInvalidDate {
+		t.Errorf("WeekBounds with week 5 returned %v", err)
+	}
+}
+
+func TestYearBounds(t *testing.T) {
+	for _, y := range []TqYear{-2, -1, 0, 1, 3, 40} {
+		s, err := YearBounds(y, boundsZone)
+		if err != nil {
+			t.Fatalf("YearBounds(%d) returned %v", y, err)
+		}
+		inside, before, after := spanDates(s)
+		if len(inside) != y.Len() {
+			t.Errorf("YearBounds(%d) contains %d days; expected %d", y, len(inside), y.Len())
+		}
+		if TqYear(before.Year) == y || TqYear(after.Year) == y {
+			t.Errorf("YearBounds(%d) misses days of the year", y)
+		}
+		if last := inside[len(inside)-1]; y.HasArmstrongDay() && last.Day != ArmstrongDay {
+			t.Errorf("YearBounds(%d) ends on %s", y, last.ShortDate())
+		}
+	}
+}
+
+func TestSpanContains(t *testing.T) {
+	s, _ := MonthBounds(3, Mendel, time.UTC)
+	var containsTests = []struct {
+		t      time.Time
+		output bool
+	}{
+		{time.Date(1972, time.June, 22, 0, 0, 0, 0, time.UTC), true},
+		{time.Date(1972, time.July, 19, 23, 59, 59, 0, time.UTC), true},
+		{time.Date(1972, time.July, 20, 0, 0, 0, 0, time.UTC), false},
+		{time.Date(1972, time.June, 21, 23, 59, 59, 0, time.UTC), false},
+		{time.Date(1972, time.June, 22, 9, 0, 0, 0, boundsZone), false},
+	}
+	for _, tt := range containsTests {
+		if s.Contains(tt.t) != tt.output {
+			t.Errorf("Mendel 3 AT contains %v: expected %v", tt.t, tt.output)
+		}
+	}
+	if _, err := MonthBounds(0, Archimedes, time.UTC); err != ErrInvalidDate {
+		t.Errorf("MonthBounds in year 0 returned %v", err)
+	}
+}
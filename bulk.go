@@ -0,0 +1,70 @@
+package tqtime
+
+import (
+	"strconv"
+	"time"
+)
+
+const secondsPerDay int64 = 24 * 60 * 60
+
+//gUnixEpochDays is the number of days from 1 January of Gregorian year 0 to 1 January 1970.
+var gUnixEpochDays = gDaysBeforeYear(1970)
+
+//FromTime returns the Tranquility date of t, using the Gregorian date of t in its own location.
+func FromTime(t time.Time) TqDate {
+	return tqDateOf(int64(t.Year()), t.YearDay())
+}
+
+//FromUnix returns the Tranquility date of the Unix time sec, given in seconds since 1 January 1970 UTC. The date is that of the UTC day containing sec.
+func FromUnix(sec int64) TqDate {
+	days, _ := floorDiv(sec, secondsPerDay)
+	return tqDateOf(gFromDayNumber(gUnixEpochDays + days))
+}
+
+//ConvertSlice writes the Tranquility date of each element of src into the corresponding element of dst, as if by FromTime. It returns the number of dates written, which is the minimum of len(dst) and len(src). ConvertSlice does not allocate.
+func ConvertSlice(dst []TqDate, src []time.Time) int {
+	n := len(src)
+	if len(dst) < n {
+		n = len(dst)
+	}
+	for i := 0; i < n; i++ {
+		dst[i] = FromTime(src[i])
+	}
+	return n
+}
+
+//ConvertUnix writes the Tranquility date of each Unix time in src into the corresponding element of dst, as if by FromUnix. It returns the number of dates written, which is the minimum of len(dst) and len(src). ConvertUnix does not allocate.
+func ConvertUnix(dst []TqDate, src []int64) int {
+	n := len(src)
+	if len(dst) < n {
+		n = len(dst)
+	}
+	for i := 0; i < n; i++ {
+		dst[i] = FromUnix(src[i])
+	}
+	return n
+}
+
+//AppendShortDate appends the compact representation of d, as returned by ShortDate, to b and returns the extended buffer. Like time.Time.AppendFormat, it allocates only if b has insufficient capacity.
+func AppendShortDate(b []byte, d TqDate) []byte {
+	b = appendDayCode(b, d)
+	b = append(b, ' ')
+	return strconv.AppendInt(b, d.Year, 10)
+}
+
+//appendDayCode appends the 3 character code at the start of a short date, such as "28M" or "ARM".
+func appendDayCode(b []byte, d TqDate) []byte {
+	if d.Day < 0 {
+		return append(b, DayCode(d.Day)...)
+	}
+	if d.Day < 10 {
+		b = append(b, '0')
+	}
+	b = strconv.AppendInt(b, int64(d.Day), 10)
+	return append(b, MonthLetter(d.Month)...)
+}
+
+//AppendLongDate appends the descriptive representation of d, as returned by LongDate, to b and returns the extended buffer. Like time.Time.AppendFormat, it allocates only if b has insufficient capacity. LongDateOptions offers other styles.
+func AppendLongDate(b []byte, d TqDate) []byte {
+	return LongDateOptions{}.Append(b, d)
+}
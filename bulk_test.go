@@ -0,0 +1,107 @@
+package tqtime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConvertSlice(t *testing.T) {
+	src := make([]time.Time, len(longTests))
+	for i, tt := range longTests {
+		src[i] = time.Date(tt.gYear, tt.gMonth, tt.gDay, 23, 59, 59, 0, time.UTC)
+	}
+	dst := make([]TqDate, len(src)+1)
+	if n := ConvertSlice(dst, src); n != len(src) {
+		t.Fatalf("ConvertSlice wrote %d dates; expected %d", n, len(src))
+	}
+	for i, tt := range longTests {
+		if actual := dst[i].LongDate(); actual != tt.output {
+			t.Errorf("ConvertSlice gave %s for %s; expected %s", actual, src[i].Format("2006-01-02"), tt.output)
+		}
+	}
+	if n := ConvertSlice(dst[:3], src); n != 3 {
+		t.Errorf("ConvertSlice wrote %d dates into a slice of 3", n)
+	}
+}
+
+func TestConvertUnix(t *testing.T) {
+	var src []int64
+	for sec := int64(-1e11); sec <= 1e11; sec += 86400*997 + 3593 {
+		src = append(src, sec)
+	}
+	dst := make([]TqDate, len(src))
+	ConvertUnix(dst, src)
+	for i, sec := range src {
+		expected := FromTime(time.Unix(sec, 0).UTC())
+		if dst[i] != expected {
+			t.Errorf("ConvertUnix gave %+v for %d; expected %+v", dst[i], sec, expected)
+		}
+	}
+}
+
+func TestAppendMatchesStrings(t *testing.T) {
+	prefix := []byte("date: ")
+	for _, tt := range shortTests {
+		gt := time.Date(tt.gYear, tt.gMonth, tt.gDay, 1, 1, 1, 1, time.UTC)
+		actual := string(AppendShortDate(prefix, FromTime(gt)))
+		if actual != "date: "+tt.output {
+			t.Errorf("AppendShortDate for %s gave %q; expected %q", gt.Format("2006-01-02"), actual, "date: "+tt.output)
+		}
+	}
+}
+
+func TestAppendAllocations(t *testing.T) {
+	d := TqDate{Year: -123456789, Month: Hippocrates, Day: 7}
+	buf := make([]byte, 0, 64)
+	allocs := testing.AllocsPerRun(100, func() {
+		AppendShortDate(buf[:0], d)
+		AppendLongDate(buf[:0], d)
+	})
+	if allocs != 0 {
+		t.Errorf("AppendShortDate and AppendLongDate made %v allocations; expected 0", allocs)
+	}
+}
+
+func BenchmarkConvertSlice(b *testing.B) {
+	src := make([]time.Time, 1024)
+	for i := range src {
+		src[i] = time.Unix(int64(i)*86400*37, 0).UTC()
+	}
+	dst := make([]TqDate, len(src))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ConvertSlice(dst, src)
+	}
+}
+
+func BenchmarkConvertUnix(b *testing.B) {
+	src := make([]int64, 1024)
+	for i := range src {
+		src[i] = int64(i-512) * 86400 * 3701
+	}
+	dst := make([]TqDate, len(src))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ConvertUnix(dst, src)
+	}
+}
+
+func BenchmarkAppendShortDate(b *testing.B) {
+	d := TqDate{Year: 55, Month: Copernicus, Day: 28}
+	buf := make([]byte, 0, 64)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf = AppendShortDate(buf[:0], d)
+	}
+}
+
+func BenchmarkAppendLongDate(b *testing.B) {
+	d := TqDate{Year: 55, Month: Copernicus, Day: 28}
+	buf := make([]byte, 0, 64)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf = AppendLongDate(buf[:0], d)
+	}
+}
@@ -0,0 +1,81 @@
+package tqtime
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+//Clock tells the time and waits for time to pass. The scheduling types of this package take a Clock so that they can be tested without sleeping.
+type Clock interface {
+	//Now returns the current time.
+	Now() time.Time
+	//After waits for the duration to elapse and then sends the current time on the returned channel.
+	After(d time.Duration) <-chan time.Time
+}
+
+//maxWait is the longest a Scheduler or TqTicker waits on its Clock at a time. Timers do not count time spent suspended, or notice the clock being changed, so they wake at least this often to read the time again.
+const maxWait = 15 * time.Minute
+
+//SystemClock is the Clock provided by the time package.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+//NowEnv is the environment variable read by ClockFromEnv. It pins the starting time of the clock used by the commands of this package, so that "today" can be tested deterministically.
+const NowEnv = "TQ_NOW"
+
+//offsetClock is a Clock that runs at the normal rate from a chosen starting time.
+type offsetClock struct {
+	start time.Time
+	began time.Time
+}
+
+func (c offsetClock) Now() time.Time {
+	return c.start.Add(time.Since(c.began))
+}
+
+func (offsetClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+//ClockFromEnv returns SystemClock, unless the environment variable named by NowEnv is set. In that case the returned Clock starts at the time given by the variable and then runs at the normal rate. The variable may hold an RFC 3339 time such as "2000-02-29T09:00:00Z", a local date such as "2000-02-29", or a Tranquility date in the format of ShortDate such as "ALD 31", which is taken as local midnight.
+func ClockFromEnv() (Clock, error) {
+	s := os.Getenv(NowEnv)
+	if s == "" {
+		return SystemClock, nil
+	}
+	start, err := parseNow(s)
+	if err != nil {
+		return nil, fmt.Errorf("tqtime: cannot parse %s=%q: %v", NowEnv, s, err)
+	}
+	return offsetClock{start: start, began: time.Now()}, nil
+}
+
+//parseNow parses the formats accepted by ClockFromEnv.
+func parseNow(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return t, nil
+	}
+	d, err := ParseShortDate(s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return d.Time(time.Local)
+}
+
+//Today returns the current Tranquility date according to clock, using the day boundaries of loc.
+func Today(clock Clock, loc *time.Location) TqDate {
+	return FromTime(clock.Now().In(loc))
+}
@@ -0,0 +1,75 @@
+package tqtime
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestToday(t *testing.T) {
+	clock := NewFakeClock(time.Date(2000, time.February, 29, 3, 0, 0, 0, time.UTC))
+	if d := Today(clock, time.UTC); d.ShortDate() != "ALD 31" {
+		t.Errorf("Today in UTC = %s; expected ALD 31", d.ShortDate())
+	}
+	if d := Today(clock, time.FixedZone("UTC-8", -8*60*60)); d.ShortDate() != "27H 31" {
+		t.Errorf("Today in UTC-8 = %s; expected 27H 31", d.ShortDate())
+	}
+	clock.Advance(24 * time.Hour)
+	if d := Today(clock, time.UTC); d.ShortDate() != "28H 31" {
+		t.Errorf("Today after a day = %s; expected 28H 31", d.ShortDate())
+	}
+}
+
+func TestFakeClockAfter(t *testing.T) {
+	clock := NewFakeClock(time.Date(2000, time.February, 29, 0, 0, 0, 0, time.UTC))
+	ch := clock.After(time.Hour)
+	clock.Advance(59 * time.Minute)
+	select {
+	case <-ch:
+		t.Error("After fired before its duration elapsed.")
+	default:
+	}
+	clock.Set(clock.Now().Add(time.Minute))
+	select {
+	case now := <-ch:
+		if !now.Equal(time.Date(2000, time.February, 29, 1, 0, 0, 0, time.UTC)) {
+			t.Errorf("After sent %v", now)
+		}
+	default:
+		t.Error("After did not fire once its duration elapsed.")
+	}
+}
+
+var clockEnvTests = []struct {
+	value  string
+	loc    *time.Location
+	output string
+}{
+	{"2000-02-29T23:00:00-08:00", time.UTC, "28H 31"},
+	{"2000-02-29", time.Local, "ALD 31"},
+	{"ALD 31", time.Local, "ALD 31"},
+	{"MNL 0", time.Local, "MNL 0"},
+}
+
+func TestClockFromEnv(t *testing.T) {
+	defer os.Unsetenv(NowEnv)
+	for _, tt := range clockEnvTests {
+		os.Setenv(NowEnv, tt.value)
+		clock, err := ClockFromEnv()
+		if err != nil {
+			t.Errorf("ClockFromEnv with %s=%q returned %v", NowEnv, tt.value, err)
+			continue
+		}
+		if d := Today(clock, tt.loc); d.ShortDate() != tt.output {
+			t.Errorf("Today with %s=%q is %s; expected %s", NowEnv, tt.value, d.ShortDate(), tt.output)
+		}
+	}
+	os.Setenv(NowEnv, "yesterday")
+	if _, err := ClockFromEnv(); err == nil {
+		t.Errorf("ClockFromEnv accepted %s=yesterday", NowEnv)
+	}
+	os.Unsetenv(NowEnv)
+	if clock, err := ClockFromEnv(); err != nil || clock != SystemClock {
+		t.Errorf("ClockFromEnv without %s = %v, %v; expected SystemClock", NowEnv, clock, err)
+	}
+}
@@ -0,0 +1,307 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/ratanvarghese/tqtime"
+	"strings"
+	"time"
+)
+
+//isoLayout is the layout of the Gregorian dates read and written by tqdate unless another is chosen.
+const isoLayout = "2006-01-02"
+
+//format returns d in the compact format of ShortDate if short is true, and in the descriptive format of LongDate otherwise.
+func format(d tqtime.TqDate, short bool) string {
+	if short {
+		return d.ShortDate()
+	}
+	return d.LongDate()
+}
+
+//parseDate reads a Tranquility date in the format of ShortDate, such as "28M 3", or a Gregorian date in any of the formats recognised by detectTime.
+func parseDate(s string) (tqtime.TqDate, error) {
+	d, err := tqtime.ParseShortDate(s)
+	if err == nil || err == tqtime.ErrInvalidDate || err == tqtime.ErrOutOfRange {
+		return d, err
+	}
+	t, err := detectTime(s, time.Local, false)
+	if err != nil {
+		return tqtime.TqDate{}, err
+	}
+	return tqtime.FromTime(t), nil
+}
+
+//The usage of the flags which let convert and reverse carry on past inputs which cannot be converted, and the error when both are given.
+const (
+	skipErrorsUsage   = "report inputs which cannot be converted on standard error and carry on, instead of stopping"
+	inlineErrorsUsage = "write inputs which cannot be converted to the output as errors, in place of their dates, and carry on"
+	bothErrorFlags    = "give -skip-errors or -inline-errors, not both"
+)
+
+func runConvert(e *env, args []string) error {
+	fs := e.flags("convert")
+	short := fs.Bool("short", false, "print the compact format, such as \"28M 3\"")
+	layout := fs.String("layout", "", "Go reference `layout` of the Gregorian dates, instead of detecting their format")
+	strict := fs.Bool("strict", false, "detect only standard formats, which have a single reading")
+	output := fs.String("output", "text", outputUsage)
+	skip := fs.Bool("skip-errors", false, skipErrorsUsage)
+	inline := fs.Bool("inline-errors", false, inlineErrorsUsage)
+	var zones zoneList
+	fs.Var(&zones, "tz", zoneUsage+"; may be repeated to write the date in each zone, separated by tabs. Without -tz, the day is the one in the zone of each date")
+	input := zoneFlag{time.Local}
+	fs.Var(&input, "input-tz", "time `zone` of dates and times which do not give their own")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	switch {
+	case *skip && *inline:
+		return usageError(bothErrorFlags)
+	case len(zones) > 1 && *output != "text":
+		return usageError("several -tz zones can only be written with -output text")
+	}
+	out, err := newRecordWriter(*output, e.stdout, *short, *inline)
+	if err != nil {
+		return err
+	}
+	var onError func(s string, err error) error
+	switch {
+	case *inline:
+		onError = out.writeError
+	case *skip:
+		onError = e.skipErrors("convert")
+	}
+	err = e.eachInput(fs.Args(), func(s string) error {
+		var t time.Time
+		var f inputFormat
+		var err error
+		if *layout != "" {
+			t, err = time.ParseInLocation(*layout, s, input.loc)
+			f.date = !layoutHasTime(*layout)
+		} else {
+			t, f, err = detectFormat(s, input.loc, *strict)
+		}
+		if err != nil {
+			return err
+		}
+		if len(zones) > 1 {
+			dates := make([]string, len(zones))
+			for i, loc := range zones {
+				dates[i] = format(tqtime.FromTime(inZone(t, !f.date, loc)), *short)
+			}
+			if _, err := fmt.Fprintln(e.stdout, strings.Join(dates, "\t")); err != nil {
+				return outputError{err}
+			}
+			return nil
+		}
+		if len(zones) == 1 {
+			t = inZone(t, !f.date, zones[0])
+		}
+		if err := out.write(newRecord(s, tqtime.FromTime(t), t.Format(isoLayout))); err != nil {
+			return outputError{err}
+		}
+		return nil
+	}, onError)
+	if ferr := out.flush(); err == nil {
+		err = ferr
+	}
+	return err
+}
+
+func runReverse(e *env, args []string) error {
+	fs := e.flags("reverse")
+	layout := fs.String("layout", isoLayout, "Go reference `layout` of the Gregorian dates")
+	output := fs.String("output", "text", outputUsage)
+	skip := fs.Bool("skip-errors", false, skipErrorsUsage)
+	inline := fs.Bool("inline-errors", false, inlineErrorsUsage)
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	var layoutSet bool
+	fs.Visit(func(f *flag.Flag) { layoutSet = layoutSet || f.Name == "layout" })
+	switch {
+	case *skip && *inline:
+		return usageError(bothErrorFlags)
+	case layoutSet && *output != "text":
+		return usageError("-layout only applies to -output text")
+	}
+	var out recordWriter
+	if *output != "text" {
+		var err error
+		if out, err = newRecordWriter(*output, e.stdout, false, *inline); err != nil {
+			return err
+		}
+	}
+	var onError func(s string, err error) error
+	switch {
+	case *inline && out != nil:
+		onError = out.writeError
+	case *inline:
+		onError = textWriter{w: e.stdout}.writeError
+	case *skip:
+		onError = e.skipErrors("reverse")
+	}
+	err := e.eachInput(fs.Args(), func(s string) error {
+		d, err := tqtime.ParseShortDate(s)
+		if err != nil {
+			return err
+		}
+		t, err := d.Time(time.Local)
+		if err != nil {
+			return err
+		}
+		if out != nil {
+			err = out.write(newRecord(s, d, t.Format(isoLayout)))
+		} else {
+			_, err = fmt.Fprintln(e.stdout, t.Format(*layout))
+		}
+		if err != nil {
+			return outputError{err}
+		}
+		return nil
+	}, onError)
+	if out != nil {
+		if ferr := out.flush(); err == nil {
+			err = ferr
+		}
+	}
+	return err
+}
+
+func runToday(e *env, args []string) error {
+	fs := e.flags("today")
+	short := fs.Bool("short", false, "print the compact format, such as \"28M 3\"")
+	var zones zoneList
+	fs.Var(&zones, "tz", zoneUsage+"; may be repeated to list the date in each zone")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageError("today takes no arguments")
+	}
+	switch len(zones) {
+	case 0:
+		fmt.Fprintln(e.stdout, format(tqtime.Today(e.clock, time.Local), *short))
+	case 1:
+		fmt.Fprintln(e.stdout, format(tqtime.Today(e.clock, zones[0]), *short))
+	default:
+		for _, loc := range zones {
+			fmt.Fprintf(e.stdout, "%s\t%s\n", loc, format(tqtime.Today(e.clock, loc), *short))
+		}
+	}
+	return nil
+}
+
+func runNext(e *env, args []string) error {
+	fs := e.flags("next")
+	short := fs.Bool("short", false, "print the compact format, such as \"ARM 3\"")
+	count := fs.Int("count", 2, "number of special days to list")
+	from := fs.String("from", "", "list the special days after this `date` instead of today")
+	zone := zoneFlag{time.Local}
+	fs.Var(&zone, "tz", zoneUsage)
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageError("next takes no arguments")
+	}
+	if *count < 0 {
+		return usageError("the count must not be negative")
+	}
+	t := e.clock.Now().In(zone.loc)
+	if *from != "" {
+		d, err := parseDate(*from)
+		if err != nil {
+			return err
+		}
+		if t, err = d.Time(zone.loc); err != nil {
+			return err
+		}
+	}
+	for i := 0; i < *count; i++ {
+		next, err := tqtime.NextArmstrongDay(t)
+		if err != nil {
+			return err
+		}
+		aldrin, err := tqtime.NextAldrinDay(t)
+		if err != nil {
+			return err
+		}
+		if aldrin.Time.Before(next.Time) {
+			next = aldrin
+		}
+		fmt.Fprintf(e.stdout, "%s\t%s\n", format(next.Date, *short), next.Time.Format(isoLayout))
+		t = next.Time
+	}
+	return nil
+}
+
+func runDiff(e *env, args []string) error {
+	fs := e.flags("diff")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		return usageError("diff takes exactly two dates")
+	}
+	from, err := parseDate(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	to, err := parseDate(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+	n, err := to.Sub(from)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(e.stdout, n)
+	return nil
+}
+
+func runRange(e *env, args []string) error {
+	fs := e.flags("range")
+	short := fs.Bool("short", false, "print the compact format, such as \"28M 3\"")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return usageError("range needs a range such as \"01A–28C 55\", or its first and last dates")
+	}
+	//Without quotes, a range such as "01A–28C 55" arrives as two arguments.
+	r, err := tqtime.ParseDateRange(strings.Join(fs.Args(), " "))
+	if err != nil && fs.NArg() == 2 {
+		var first, last tqtime.TqDate
+		if first, err = parseDate(fs.Arg(0)); err != nil {
+			return err
+		}
+		if last, err = parseDate(fs.Arg(1)); err != nil {
+			return err
+		}
+		r, err = tqtime.NewDateRange(first, last)
+	}
+	if err != nil {
+		return err
+	}
+	r.Each(func(d tqtime.TqDate) bool {
+		fmt.Fprintln(e.stdout, format(d, *short))
+		return true
+	})
+	return nil
+}
+
+func runHelp(e *env, args []string) error {
+	switch {
+	case len(args) == 0 || args[0] == "help":
+		e.usage()
+		return nil
+	case len(args) > 1:
+		return usageError("help takes one command")
+	}
+	cmd := lookup(args[0])
+	if cmd == nil {
+		return usageError(fmt.Sprintf("unknown command %q", args[0]))
+	}
+	return cmd.run(e, []string{"-help"})
+}
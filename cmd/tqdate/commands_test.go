@@ -0,0 +1,45 @@
+package main
+
+import "testing"
+
+func TestCommands(t *testing.T) {
+	var commandTests = []struct {
+		stdin  string
+		args   []string
+		output string
+	}{
+		{"", []string{"convert", "1972-07-19"}, "Thursday, 28 Mendel, 3 After Tranquility\n"},
+		{"", []string{"convert", "-short", "1969-07-20", "2000-02-29"}, "MNL 0\nALD 31\n"},
+		{"1969-07-19\n  2001-07-20  \n", []string{"convert", "-short"}, "28M -1\nARM 32\n"},
+		{"", []string{"convert", "-short", "-layout", "02/01/2006", "19/07/1972"}, "28M 3\n"},
+		{"", []string{"convert", "-short", "-layout", "2006-01-02T15:04:05Z07:00", "2000-02-29T23:30:00-08:00"}, "ALD 31\n"},
+		{"", []string{"convert", "-short", "1972-07-20T01:00+10:00", "1972-07-19T15:00Z"}, "ARM 3\n28M 3\n"},
+		{"", []string{"convert", "-short", "-tz", "UTC", "1972-07-20T01:00+10:00", "1972-07-19T15:00Z"}, "28M 3\n28M 3\n"},
+		{"", []string{"convert", "-short", "-tz", "-08:00", "-tz", "UTC", "-tz", "+10", "1972-07-19T20:00Z", "19/07/1972"}, "28M 3\t28M 3\tARM 3\n28M 3\t28M 3\t28M 3\n"},
+		{"", []string{"convert", "-short", "-input-tz", "+10", "-tz", "UTC", "1972-07-20 01:00", "1972-07-20"}, "28M 3\nARM 3\n"},
+		{"", []string{"convert", "-short", "-input-tz", "+14", "-tz", "-12", "-layout", "02/01/2006", "20/07/1972"}, "ARM 3\n"},
+		{"", []string{"reverse", "28M 3", "ALD 31", "MNL 0"}, "1972-07-19\n2000-02-29\n1969-07-20\n"},
+		{"01A 1\n", []string{"reverse", "-layout", "Jan 2 2006"}, "Jul 21 1969\n"},
+		{"", []string{"today"}, "Aldrin Day, 31 After Tranquility\n"},
+		{"", []string{"today", "-short"}, "ALD 31\n"},
+		{"", []string{"today", "-short", "-tz", "-08:00"}, "27H 31\n"},
+		{"", []string{"today", "-short", "-tz", "America/Los_Angeles", "-tz", "UTC"}, "America/Los_Angeles\t27H 31\nUTC\tALD 31\n"},
+		{"", []string{"next", "-short"}, "ARM 31\t2000-07-20\nARM 32\t2001-07-20\n"},
+		{"", []string{"next", "-short", "-count", "3", "-from", "28M 54"}, "ARM 54\t2023-07-20\nALD 55\t2024-02-29\nARM 55\t2024-07-20\n"},
+		{"", []string{"next", "-from", "2023-07-20", "-count", "1"}, "Aldrin Day, 55 After Tranquility\t2024-02-29\n"},
+		{"", []string{"next", "-short", "-count", "1", "-tz", "+14"}, "ARM 31\t2000-07-20\n"},
+		{"", []string{"diff", "27H 31", "28H 31"}, "2\n"},
+		{"", []string{"diff", "2000-02-29", "ALD 27"}, "-1461\n"},
+		{"", []string{"diff", "01A 55", "ARM 55"}, "365\n"},
+		{"", []string{"range", "-short", "27H 31–28H 31"}, "27H 31\nALD 31\n28H 31\n"},
+		{"", []string{"range", "-short", "28M", "-1-01A", "1"}, "28M -1\nMNL 0\n01A 1\n"},
+		{"", []string{"range", "-short", "2000-02-28", "01I 31"}, "27H 31\nALD 31\n28H 31\n01I 31\n"},
+		{"", []string{"range", "ARM 3–01A 4"}, "Armstrong Day, 3 After Tranquility\nFriday, 1 Archimedes, 4 After Tranquility\n"},
+	}
+	for _, tt := range commandTests {
+		code, stdout, stderr := runTest(t, tt.stdin, tt.args...)
+		if code != exitOK || stdout != tt.output {
+			t.Errorf("tqdate %q = %d, %q, %q; expected %q", tt.args, code, stdout, stderr, tt.output)
+		}
+	}
+}
@@ -0,0 +1,264 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"github.com/ratanvarghese/tqtime"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+//csvColumn is a column converted by the csv command. It is given with -column as COLUMN[|LAYOUT[|ZONE]], where COLUMN is a name from the header or a number counting from 1, LAYOUT is a Go reference layout, and ZONE is the time zone of values which do not give their own.
+type csvColumn struct {
+	name   string
+	index  int
+	layout string
+	loc    *time.Location
+}
+
+//columnList is a flag.Value collecting the columns given with -column.
+type columnList []csvColumn
+
+func (c *columnList) String() string {
+	names := make([]string, len(*c))
+	for i, col := range *c {
+		names[i] = col.name
+	}
+	return strings.Join(names, " ")
+}
+
+func (c *columnList) Set(s string) error {
+	parts := strings.SplitN(s, "|", 3)
+	col := csvColumn{name: parts[0], index: -1, loc: time.Local}
+	if col.name == "" {
+		return errors.New("the column is empty")
+	}
+	if n, err := strconv.Atoi(col.name); err == nil {
+		if n < 1 {
+			return fmt.Errorf("column numbers start at 1, not %d", n)
+		}
+		col.index = n - 1
+	}
+	if len(parts) > 1 {
+		col.layout = parts[1]
+	}
+	if len(parts) > 2 {
+		var err error
+		if col.loc, err = parseZone(parts[2]); err != nil {
+			return err
+		}
+	}
+	*c = append(*c, col)
+	return nil
+}
+
+//resolve finds the index of each named column in header.
+func (c columnList) resolve(header []string) error {
+	for i := range c {
+		if c[i].index >= 0 {
+			continue
+		}
+		if header == nil {
+			return fmt.Errorf("column %q is named, but there is no header", c[i].name)
+		}
+		for j, h := range header {
+			if h == c[i].name {
+				c[i].index = j
+				break
+			}
+		}
+		if c[i].index < 0 {
+			return fmt.Errorf("there is no column named %q", c[i].name)
+		}
+	}
+	return nil
+}
+
+//cellError describes a cell which could not be converted.
+type cellError struct {
+	row    int
+	column string
+	err    error
+}
+
+func (c cellError) Error() string {
+	return fmt.Sprintf("row %d, column %s: %v", c.row, c.column, c.err)
+}
+
+//csvConverter converts the date columns of CSV records.
+type csvConverter struct {
+	columns columnList
+	replace bool
+	convert func(t time.Time) string
+}
+
+//header returns the header of the output, given the header of the input.
+func (c *csvConverter) header(in []string, suffix string) []string {
+	if c.replace {
+		return in
+	}
+	out := append([]string(nil), in...)
+	for _, col := range c.columns {
+		name := col.name
+		if col.index < len(in) {
+			name = in[col.index]
+		}
+		out = append(out, name+suffix)
+	}
+	return out
+}
+
+//row converts the date columns of the record in, which is row number n of the input, and returns the output record in out, reusing its storage. Empty cells stay empty. The first cell which cannot be converted is returned as a cellError, and the row is completed with that cell left as it was, or empty when columns are appended.
+func (c *csvConverter) row(out, in []string, n int) ([]string, error) {
+	out = append(out[:0], in...)
+	var firstErr error
+	for _, col := range c.columns {
+		var cell, s string
+		if col.index < len(in) {
+			cell = in[col.index]
+		}
+		if strings.TrimSpace(cell) != "" {
+			t, err := parseCell(cell, col)
+			if err == nil {
+				s = c.convert(t)
+			} else if firstErr == nil {
+				firstErr = cellError{n, col.name, err}
+			}
+		}
+		switch {
+		case !c.replace:
+			out = append(out, s)
+		case s != "":
+			out[col.index] = s
+		}
+	}
+	return out, firstErr
+}
+
+//parseCell reads the date in a cell of col.
+func parseCell(cell string, col csvColumn) (time.Time, error) {
+	if col.layout != "" {
+		return time.ParseInLocation(col.layout, strings.TrimSpace(cell), col.loc)
+	}
+	return detectTime(cell, col.loc, false)
+}
+
+func runCSV(e *env, args []string) error {
+	fs := e.flags("csv")
+	var columns columnList
+	fs.Var(&columns, "column", "date `column` to convert, as COLUMN[|LAYOUT[|ZONE]] where COLUMN is a header name or a number from 1; may be repeated")
+	short := fs.Bool("short", false, "write the compact format, such as \"28M 3\"")
+	var directives layoutFlag
+	fs.Var(&directives, "format", "write dates in `layout`, with the directives of tqtime.FormatTime such as \"%F %T\"")
+	mode := fs.String("mode", "append", "\"append\" to add a column for each converted column, or \"replace\" to replace the dates")
+	suffix := fs.String("suffix", " (Tranquility)", "added to the header of each appended column")
+	header := fs.Bool("header", true, "treat the first row as a header")
+	delimiter := fs.String("delimiter", ",", "field `character` separating the columns")
+	skip := fs.Bool("skip-errors", false, "report cells which cannot be converted and carry on, instead of stopping")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	c := csvConverter{columns: columns}
+	switch *mode {
+	case "append":
+	case "replace":
+		c.replace = true
+	default:
+		return usageError(fmt.Sprintf("unknown mode %q; the modes are append and replace", *mode))
+	}
+	comma, size := utf8.DecodeRuneInString(*delimiter)
+	switch {
+	case len(columns) == 0:
+		return usageError("give the date columns with -column")
+	case size == 0 || size != len(*delimiter):
+		return usageError("the delimiter must be one character")
+	case fs.NArg() > 1:
+		return usageError("csv reads one file")
+	}
+	c.convert = func(t time.Time) string {
+		if directives != "" {
+			return tqtime.FormatTime(t, string(directives))
+		}
+		return format(tqtime.FromTime(t), *short)
+	}
+
+	in := e.stdin
+	if fs.NArg() == 1 {
+		f, err := os.Open(fs.Arg(0))
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+	r := csv.NewReader(in)
+	r.Comma = comma
+	r.FieldsPerRecord = -1
+	r.ReuseRecord = true
+	w := csv.NewWriter(e.stdout)
+	w.Comma = comma
+	err := c.copy(r, w, *header, *suffix, func(err error) error {
+		if !*skip {
+			return err
+		}
+		fmt.Fprintf(e.stderr, "tqdate csv: %v\n", err)
+		return nil
+	})
+	w.Flush()
+	if err == nil {
+		err = w.Error()
+	}
+	return err
+}
+
+//copy converts the records of r and writes them to w, one at a time so that large files use little memory. Cells which cannot be converted are passed to onError, and copying stops if it returns an error. If there were any, copy returns a summary error after the last record.
+func (c *csvConverter) copy(r *csv.Reader, w *csv.Writer, header bool, suffix string, onError func(error) error) error {
+	var out []string
+	var failed int
+	for n := 1; ; n++ {
+		in, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if n == 1 {
+			var names []string
+			if header {
+				names = in
+			}
+			if err := c.columns.resolve(names); err != nil {
+				return err
+			}
+			if header {
+				if err := w.Write(c.header(in, suffix)); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		out, err = c.row(out, in, n)
+		if err != nil {
+			failed++
+			if err = onError(err); err != nil {
+				return err
+			}
+		}
+		if err := w.Write(out); err != nil {
+			return err
+		}
+	}
+	switch {
+	case failed == 1:
+		return errors.New("1 row had a date which could not be converted")
+	case failed > 1:
+		return fmt.Errorf("%d rows had dates which could not be converted", failed)
+	}
+	return nil
+}
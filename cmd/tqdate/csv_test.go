@@ -0,0 +1,91 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+const csvInput = "id,posted,amount,settled\n" +
+	"1,2000-02-29,\"1,000\",19/07/1972 23:00\n" +
+	"2,,5,bad\n" +
+	"3,1969-07-20,7,20/07/1972 01:00\n"
+
+func TestCSV(t *testing.T) {
+	var csvTests = []struct {
+		args   []string
+		input  string
+		output string
+	}{
+		{
+			[]string{"csv", "-short", "-column", "posted"},
+			"id,posted\n1,2000-02-29\n2,\n3,July 20 1969\n",
+			"id,posted,posted (Tranquility)\n1,2000-02-29,ALD 31\n2,,\n3,July 20 1969,MNL 0\n",
+		},
+		{
+			[]string{"csv", "-column", "2", "-suffix", "_tq", "-format", "%a %F"},
+			"id,posted\n1,2000-02-28\n",
+			"id,posted,posted_tq\n1,2000-02-28,Wed 27H 31\n",
+		},
+		{
+			[]string{"csv", "-short", "-mode", "replace", "-header=false", "-delimiter", ";", "-column", "1|2006-01-02T15:04Z07:00|UTC", "-column", "3|02/01/2006 15:04|+10:00"},
+			"1972-07-19T23:00+00:00;x;19/07/1972 23:00\n",
+			"28M 3;x;28M 3\n",
+		},
+		{
+			//Values are read, and their day found, in the time zone of the column.
+			[]string{"csv", "-short", "-header=false", "-column", "1|2006-01-02 15:04|+10:00", "-format", "%F %:z"},
+			"1972-07-20 01:00\n",
+			"1972-07-20 01:00,ARM 3 +10:00\n",
+		},
+	}
+	for _, tt := range csvTests {
+		code, stdout, stderr := runTest(t, tt.input, tt.args...)
+		if code != exitOK || stdout != tt.output {
+			t.Errorf("tqdate %q = %d, %q, %q; expected %q", tt.args, code, stdout, stderr, tt.output)
+		}
+	}
+}
+
+func TestCSVErrors(t *testing.T) {
+	args := []string{"csv", "-short", "-column", "posted", "-column", "4|02/01/2006 15:04|UTC"}
+	code, stdout, stderr := runTest(t, csvInput, args...)
+	if code != exitFailure || strings.Count(stdout, "\n") != 2 {
+		t.Errorf("stopped with %d after writing %q; expected the header and one row", code, stdout)
+	}
+	if !strings.HasPrefix(stderr, "tqdate csv: row 3, column 4: ") {
+		t.Errorf("error did not name the row and column: %q", stderr)
+	}
+
+	code, stdout, stderr = runTest(t, csvInput, append(args, "-skip-errors")...)
+	if code != exitFailure || strings.Count(stdout, "\n") != 4 {
+		t.Errorf("skipping errors exited with %d after writing %q; expected every row", code, stdout)
+	}
+	if !strings.Contains(stdout, "\n2,,5,bad,,\n") {
+		t.Errorf("the row with an error was not kept with empty Tranquility cells: %q", stdout)
+	}
+	if !strings.Contains(stderr, "row 3, column 4") || !strings.Contains(stderr, "1 row had a date") {
+		t.Errorf("skipped errors were not reported with a summary: %q", stderr)
+	}
+}
+
+func TestCSVUsage(t *testing.T) {
+	var usageTests = []struct {
+		args []string
+		code int
+	}{
+		{[]string{"csv"}, exitUsage},
+		{[]string{"csv", "-column", "0"}, exitUsage},
+		{[]string{"csv", "-column", "posted|2006|Mars/Olympus_Mons"}, exitUsage},
+		{[]string{"csv", "-column", "posted", "-mode", "merge"}, exitUsage},
+		{[]string{"csv", "-column", "posted", "-delimiter", "::"}, exitUsage},
+		{[]string{"csv", "-column", "posted", "a.csv", "b.csv"}, exitUsage},
+		{[]string{"csv", "-column", "missing"}, exitFailure},
+		{[]string{"csv", "-column", "posted", "-header=false"}, exitFailure},
+		{[]string{"csv", "-column", "posted", "no-such-file.csv"}, exitFailure},
+	}
+	for _, tt := range usageTests {
+		if code, _, _ := runTest(t, csvInput, tt.args...); code != tt.code {
+			t.Errorf("tqdate %q exited with %d, expected %d", tt.args, code, tt.code)
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/ratanvarghese/tqtime"
+	"os"
+	"strings"
+	"time"
+)
+
+//defaultDateLayout is the layout printed by the date command when no other is chosen. Like date(1), it includes the time and the time zone.
+const defaultDateLayout = "%x %T %Z"
+
+//isoDateLayouts maps the TIMESPEC arguments of -I to layouts for tqtime.FormatTime. They follow the ISO 8601 output of date(1), with the compact Tranquility date in place of the Gregorian date.
+var isoDateLayouts = map[string]string{
+	"date":    "%F",
+	"hours":   "%FT%H%:z",
+	"minutes": "%FT%R%:z",
+	"seconds": "%FT%T%:z",
+	"ns":      "%FT%T,%N%:z",
+}
+
+//layoutFlag is a flag.Value holding a layout for tqtime.FormatTime. Layouts with directives that FormatTime does not understand are rejected, rather than printing them unchanged.
+type layoutFlag string
+
+func (l *layoutFlag) String() string {
+	return string(*l)
+}
+
+func (l *layoutFlag) Set(s string) error {
+	if err := tqtime.CheckLayout(s); err != nil {
+		return err
+	}
+	*l = layoutFlag(s)
+	return nil
+}
+
+//dateOptions are the options of date(1) understood by the date command.
+type dateOptions struct {
+	date      string
+	hasDate   bool
+	reference string
+	utc       bool
+	iso       string
+	layout    string
+	hasLayout bool
+}
+
+//parseDateArgs reads the command line of the date command in the style of date(1): short options may be grouped as in "-uI", and long options may take their argument after '='.
+func parseDateArgs(args []string) (dateOptions, error) {
+	var o dateOptions
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		//value returns the argument of an option, which is either attached to it or the next argument.
+		value := func(name, attached string) (string, error) {
+			if attached != "" {
+				return attached, nil
+			}
+			if i+1 == len(args) {
+				return "", usageError(fmt.Sprintf("option %s requires an argument", name))
+			}
+			i++
+			return args[i], nil
+		}
+		var err error
+		switch {
+		case a == "--":
+			for _, operand := range args[i+1:] {
+				if err = o.setLayout(operand); err != nil {
+					return o, err
+				}
+			}
+			i = len(args)
+		case strings.HasPrefix(a, "--"):
+			name, attached := a[2:], ""
+			hasValue := false
+			if eq := strings.Index(name, "="); eq >= 0 {
+				name, attached, hasValue = name[:eq], name[eq+1:], true
+			}
+			switch name {
+			case "date":
+				if hasValue {
+					o.date = attached
+				} else {
+					o.date, err = value("--date", "")
+				}
+				o.hasDate = true
+			case "reference":
+				if hasValue {
+					o.reference = attached
+				} else {
+					o.reference, err = value("--reference", "")
+				}
+			case "utc", "universal":
+				o.utc = true
+			case "iso-8601":
+				o.iso = "date"
+				if hasValue {
+					o.iso = attached
+				}
+			case "help":
+				return o, flag.ErrHelp
+			default:
+				return o, usageError(fmt.Sprintf("unrecognized option %q", a))
+			}
+		case strings.HasPrefix(a, "-") && len(a) > 1:
+			for j := 1; j < len(a); j++ {
+				switch a[j] {
+				case 'u':
+					o.utc = true
+					continue
+				case 'd':
+					o.date, err = value("-d", a[j+1:])
+					o.hasDate = true
+				case 'r':
+					o.reference, err = value("-r", a[j+1:])
+				case 'I':
+					o.iso = "date"
+					if a[j+1:] != "" {
+						o.iso = a[j+1:]
+					}
+				case 'h':
+					return o, flag.ErrHelp
+				default:
+					return o, usageError(fmt.Sprintf("invalid option -- '%c'", a[j]))
+				}
+				//The rest of the group was the argument of the option.
+				break
+			}
+		default:
+			err = o.setLayout(a)
+		}
+		if err != nil {
+			return o, err
+		}
+	}
+	switch {
+	case o.hasDate && o.reference != "":
+		return o, usageError("the options to specify dates for printing are mutually exclusive")
+	case o.iso != "" && o.hasLayout:
+		return o, usageError("multiple output formats specified")
+	case o.iso != "" && isoDateLayouts[o.iso] == "":
+		return o, usageError(fmt.Sprintf("invalid argument %q for --iso-8601; valid arguments are date, hours, minutes, seconds and ns", o.iso))
+	}
+	return o, nil
+}
+
+//setLayout records the +FORMAT operand of the date command.
+func (o *dateOptions) setLayout(operand string) error {
+	switch {
+	case !strings.HasPrefix(operand, "+"):
+		return usageError(fmt.Sprintf("invalid date %q; formats start with '+'", operand))
+	case o.hasLayout:
+		return usageError(fmt.Sprintf("extra operand %q", operand))
+	}
+	if err := tqtime.CheckLayout(operand[1:]); err != nil {
+		return usageError(err.Error())
+	}
+	o.layout, o.hasLayout = operand[1:], true
+	return nil
+}
+
+//parseDateString reads the argument of -d. Besides the Gregorian formats recognised by detectTime, it accepts "now", "today", "yesterday", "tomorrow" and Tranquility dates in the format of ShortDate. Dates without a time zone are taken to be in loc.
+func parseDateString(s string, now time.Time, loc *time.Location) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	switch strings.ToLower(s) {
+	case "", "now", "today":
+		return now, nil
+	case "yesterday":
+		return now.AddDate(0, 0, -1), nil
+	case "tomorrow":
+		return now.AddDate(0, 0, 1), nil
+	}
+	if d, err := tqtime.ParseShortDate(s); err == nil {
+		return d.Time(loc)
+	}
+	return detectTime(s, loc, false)
+}
+
+func runDate(e *env, args []string) error {
+	o, err := parseDateArgs(args)
+	if err == flag.ErrHelp {
+		e.dateUsage()
+	}
+	if err != nil {
+		return err
+	}
+	loc := time.Local
+	if o.utc {
+		loc = time.UTC
+	}
+	t := e.clock.Now()
+	switch {
+	case o.hasDate:
+		if t, err = parseDateString(o.date, t.In(loc), loc); err != nil {
+			return err
+		}
+	case o.reference != "":
+		fi, err := os.Stat(o.reference)
+		if err != nil {
+			return err
+		}
+		t = fi.ModTime()
+	}
+	layout := defaultDateLayout
+	switch {
+	case o.iso != "":
+		layout = isoDateLayouts[o.iso]
+	case o.hasLayout:
+		layout = o.layout
+	}
+	fmt.Fprintln(e.stdout, tqtime.FormatTime(t.In(loc), layout))
+	return nil
+}
+
+//dateUsage describes the date command, which is also run when tqdate is given options instead of a command.
+func (e *env) dateUsage() {
+	cmd := lookup("date")
+	fmt.Fprintf(e.stderr, `usage: tqdate %s
+   or: tqdate date %[1]s
+
+Print a date in the Tranquility calendar, like date(1).
+
+Options:
+  -d, --date=STRING       print the date described by STRING instead of now
+  -r, --reference=FILE    print the modification time of FILE
+  -u, --utc, --universal  use UTC instead of the local time zone
+  -I[TIMESPEC], --iso-8601[=TIMESPEC]
+                          print the compact date and, for TIMESPEC hours,
+                          minutes, seconds or ns, the ISO 8601 time of day
+
+FORMAT is copied to the output except for directives, such as %%F for
+"28M 3" and %%x for "Thursday, 28 Mendel, 3 After Tranquility". The default
+is "+%s". Run "go doc github.com/ratanvarghese/tqtime.FormatTime" for the
+full list of directives.
+`, cmd.args, defaultDateLayout)
+}
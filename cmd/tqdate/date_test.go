@@ -0,0 +1,80 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestDate(t *testing.T) {
+	var dateTests = []struct {
+		args   []string
+		output string
+	}{
+		{[]string{"-I"}, "ALD 31\n"},
+		{[]string{"date", "+%A %F"}, "Aldrin Day ALD 31\n"},
+		{[]string{"-u", "-d", "1972-07-19T09:30:00Z"}, "Thursday, 28 Mendel, 3 After Tranquility 09:30:00 UTC\n"},
+		{[]string{"-ud", "1972-07-19 09:30", "-Iminutes"}, "28M 3T09:30+00:00\n"},
+		{[]string{"--utc", "--date=1972-07-19T23:30:00-02:00", "--iso-8601=hours"}, "ARM 3T01+00:00\n"},
+		{[]string{"-u", "--date", "@0", "-Ins"}, "25F 1T00:00:00,000000000+00:00\n"},
+		{[]string{"--universal", "-d@86400", "+%s %K"}, "86400 26F\n"},
+		{[]string{"-u", "-d", "ARM 3", "--", "+%x"}, "Armstrong Day, 3 After Tranquility\n"},
+		{[]string{"-d", "Wed Jul 19 09:30:00 UTC 1972", "-uIdate"}, "28M 3\n"},
+		{[]string{"-d", "yesterday", "+%F"}, "27H 31\n"},
+		{[]string{"-d", "tomorrow", "+%F"}, "28H 31\n"},
+		{[]string{"-u", "-d", "1969-07-23T07:05:00Z", "+%-d %_m %y %l:%M %P"}, "3  1 01  7:05 am\n"},
+	}
+	for _, tt := range dateTests {
+		code, stdout, stderr := runTest(t, "", tt.args...)
+		if code != exitOK || stdout != tt.output {
+			t.Errorf("tqdate %q = %d, %q, %q; expected %q", tt.args, code, stdout, stderr, tt.output)
+		}
+	}
+}
+
+func TestDateReference(t *testing.T) {
+	f, err := ioutil.TempFile("", "tqdate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	defer os.Remove(f.Name())
+	mtime := time.Date(1972, time.July, 19, 9, 30, 0, 0, time.UTC)
+	if err := os.Chtimes(f.Name(), mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+	code, stdout, stderr := runTest(t, "", "-u", "-r", f.Name(), "-Iseconds")
+	if code != exitOK || stdout != "28M 3T09:30:00+00:00\n" {
+		t.Errorf("tqdate -r = %d, %q, %q", code, stdout, stderr)
+	}
+	if code, _, _ := runTest(t, "", "-r", f.Name()+".missing"); code != exitFailure {
+		t.Errorf("tqdate -r of a missing file exited with %d", code)
+	}
+}
+
+func TestDateUsage(t *testing.T) {
+	var usageTests = []struct {
+		args []string
+		code int
+	}{
+		{[]string{"--help"}, exitOK},
+		{[]string{"date", "--help"}, exitOK},
+		{[]string{"help", "date"}, exitOK},
+		{[]string{"-x"}, exitUsage},
+		{[]string{"--frob"}, exitUsage},
+		{[]string{"-d"}, exitUsage},
+		{[]string{"-d", "today", "-r", "file"}, exitUsage},
+		{[]string{"-I", "+%F"}, exitUsage},
+		{[]string{"-Ifortnight"}, exitUsage},
+		{[]string{"+%F", "+%x"}, exitUsage},
+		{[]string{"+%F %G"}, exitUsage},
+		{[]string{"date", "tomorrow"}, exitUsage},
+		{[]string{"-d", "next blue moon"}, exitFailure},
+	}
+	for _, tt := range usageTests {
+		if code, _, _ := runTest(t, "", tt.args...); code != tt.code {
+			t.Errorf("tqdate %q exited with %d, expected %d", tt.args, code, tt.code)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//inputFormat is a Gregorian format recognised by detectTime. Standard formats have a single reading, and are the only ones accepted in strict mode. Date formats have no time of day, so they name a whole day rather than an instant.
+type inputFormat struct {
+	name     string
+	standard bool
+	date     bool
+	parse    func(s string, loc *time.Location) (time.Time, bool)
+}
+
+//layoutFormat returns an inputFormat which accepts any of the Go reference layouts.
+func layoutFormat(name string, standard, date bool, layouts ...string) inputFormat {
+	return inputFormat{name, standard, date, func(s string, loc *time.Location) (time.Time, bool) {
+		for _, layout := range layouts {
+			if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+				return t, true
+			}
+		}
+		return time.Time{}, false
+	}}
+}
+
+//inputFormats are the formats tried by detectTime. Each string should be accepted by at most one standard format, so that only the locale forms can be ambiguous.
+var inputFormats = []inputFormat{
+	layoutFormat("RFC 3339", true, false, time.RFC3339Nano, "2006-01-02T15:04:05.999999999", "2006-01-02T15:04Z07:00", "2006-01-02T15:04", "2006-01-02 15:04:05.999999999Z07:00", "2006-01-02 15:04:05.999999999", "2006-01-02 15:04Z07:00", "2006-01-02 15:04", "2006-01-02T15:04:05.999999999Z0700", "2006-01-02T15:04Z0700", "2006-01-02 15:04:05.999999999Z0700", "2006-01-02 15:04Z0700"),
+	layoutFormat("ISO 8601 date", true, true, "2006-01-02", "20060102"),
+	{"ISO 8601 week date", true, true, parseWeekDate},
+	{"ISO 8601 ordinal date", true, true, parseOrdinalDate},
+	layoutFormat("RFC 1123", true, false, time.RFC1123Z, time.RFC1123, "Mon, 2 Jan 2006 15:04:05 -0700", "Mon, 2 Jan 2006 15:04:05 MST"),
+	layoutFormat("RFC 822", true, false, time.RFC822Z, time.RFC822, time.RFC850),
+	layoutFormat("date(1)", true, false, time.UnixDate, time.ANSIC),
+	layoutFormat("Common Log Format", true, false, "02/Jan/2006:15:04:05 -0700"),
+	{"Unix seconds", true, false, parseUnixSeconds},
+	{"Unix timestamp", false, false, parseUnixTimestamp},
+	layoutFormat("day/month/year", false, true, "2/1/2006", "2.1.2006"),
+	layoutFormat("month/day/year", false, true, "1/2/2006"),
+	layoutFormat("year/month/day", false, true, "2006/1/2"),
+	layoutFormat("day month year", false, true, "2 January 2006", "2 Jan 2006", "Monday, 2 January 2006", "Mon, 2 Jan 2006"),
+	layoutFormat("month day, year", false, true, "January 2, 2006", "Jan 2, 2006", "January 2 2006", "Jan 2 2006", "Monday, January 2, 2006", "Mon, Jan 2, 2006"),
+}
+
+var weekDatePattern = regexp.MustCompile(`^(\d{4})-?W(\d{2})(?:-?([1-7]))?$`)
+
+//parseWeekDate reads an ISO 8601 week date such as "2024-W05-3" or "2024W053". Without a day of the week, it is the Monday of the week.
+func parseWeekDate(s string, loc *time.Location) (time.Time, bool) {
+	m := weekDatePattern.FindStringSubmatch(s)
+	if m == nil {
+		return time.Time{}, false
+	}
+	year, _ := strconv.Atoi(m[1])
+	week, _ := strconv.Atoi(m[2])
+	day := 1
+	if m[3] != "" {
+		day, _ = strconv.Atoi(m[3])
+	}
+	//Week 1 is the week containing 4 January, and weeks start on Monday.
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, loc)
+	t := jan4.AddDate(0, 0, (week-1)*7+day-1-(int(jan4.Weekday())+6)%7)
+	if y, w := t.ISOWeek(); y != year || w != week {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+var ordinalDatePattern = regexp.MustCompile(`^(\d{4})-?(\d{3})$`)
+
+//parseOrdinalDate reads an ISO 8601 ordinal date such as "2024-031" or "2024031".
+func parseOrdinalDate(s string, loc *time.Location) (time.Time, bool) {
+	m := ordinalDatePattern.FindStringSubmatch(s)
+	if m == nil {
+		return time.Time{}, false
+	}
+	year, _ := strconv.Atoi(m[1])
+	day, _ := strconv.Atoi(m[2])
+	t := time.Date(year, time.January, day, 0, 0, 0, 0, loc)
+	if day < 1 || t.Year() != year {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+//parseUnixSeconds reads Unix seconds written as "@SECONDS", as accepted by date(1).
+func parseUnixSeconds(s string, loc *time.Location) (time.Time, bool) {
+	if !strings.HasPrefix(s, "@") {
+		return time.Time{}, false
+	}
+	sec, err := strconv.ParseInt(s[1:], 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(sec, 0).In(loc), true
+}
+
+//parseUnixTimestamp reads a bare Unix timestamp. Numbers of 9 to 11 digits are taken as seconds, from 1973 to 5138, and numbers of 12 to 14 digits as milliseconds. Shorter numbers are left to the ISO 8601 basic formats.
+func parseUnixTimestamp(s string, loc *time.Location) (time.Time, bool) {
+	if len(s) < 9 || len(s) > 14 {
+		return time.Time{}, false
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || s[0] == '+' || s[0] == '-' {
+		return time.Time{}, false
+	}
+	if len(s) <= 11 {
+		return time.Unix(n, 0).In(loc), true
+	}
+	return time.Unix(n/1000, n%1000*int64(time.Millisecond)).In(loc), true
+}
+
+//detectTime reads s in whichever of inputFormats accepts it, using loc for times without a zone. An error is returned if no format accepts s, or if two formats accept it with different results, such as "03/04/2021". In strict mode only the standard formats are tried.
+func detectTime(s string, loc *time.Location, strict bool) (time.Time, error) {
+	t, _, err := detectFormat(s, loc, strict)
+	return t, err
+}
+
+//detectFormat is like detectTime, but also returns the format which accepted s.
+func detectFormat(s string, loc *time.Location, strict bool) (time.Time, inputFormat, error) {
+	s = strings.TrimSpace(s)
+	var found time.Time
+	var foundFormat inputFormat
+	for _, f := range inputFormats {
+		if strict && !f.standard {
+			continue
+		}
+		t, ok := f.parse(s, loc)
+		switch {
+		case !ok:
+			continue
+		case foundFormat.name == "":
+			found, foundFormat = t, f
+		case !t.Equal(found):
+			return time.Time{}, inputFormat{}, fmt.Errorf("ambiguous date %q: as %s it is %s, but as %s it is %s", s, foundFormat.name, found.Format(isoLayout), f.name, t.Format(isoLayout))
+		}
+	}
+	switch {
+	case foundFormat.name != "":
+		return found, foundFormat, nil
+	case strict:
+		return time.Time{}, inputFormat{}, fmt.Errorf("unrecognised date %q; strict mode accepts RFC 3339, ISO 8601, RFC 1123, RFC 822, date(1) and @SECONDS", s)
+	}
+	return time.Time{}, inputFormat{}, fmt.Errorf("unrecognised date %q", s)
+}
+
+//layoutHasTime reports whether the Go reference layout has a time of day, by writing two times of the same day with it.
+func layoutHasTime(layout string) bool {
+	day := time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)
+	return day.Format(layout) != day.Add(13*time.Hour+4*time.Minute+5*time.Second+999999999).Format(layout)
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDetectTime(t *testing.T) {
+	var detectTests = []struct {
+		input  string
+		strict bool
+		output string
+	}{
+		{"1972-07-19T09:30:00Z", true, "1972-07-19T09:30:00Z"},
+		{"1972-07-19T09:30:00.25+10:00", true, "1972-07-19T09:30:00.25+10:00"},
+		{"1972-07-19 09:30", true, "1972-07-19T09:30:00Z"},
+		{"1972-07-19T09:30+10:00", true, "1972-07-19T09:30:00+10:00"},
+		{"2000-02-28T23:30:00-0800", true, "2000-02-28T23:30:00-08:00"},
+		{"1972-07-19 09:30:00.25+1000", true, "1972-07-19T09:30:00.25+10:00"},
+		{"1972-07-19", true, "1972-07-19T00:00:00Z"},
+		{"19720719", true, "1972-07-19T00:00:00Z"},
+		{"1972-W29-3", true, "1972-07-19T00:00:00Z"},
+		{"1972W293", true, "1972-07-19T00:00:00Z"},
+		{"1972-W29", true, "1972-07-17T00:00:00Z"},
+		{"2020-W53-7", true, "2021-01-03T00:00:00Z"},
+		{"2021-W01-1", true, "2021-01-04T00:00:00Z"},
+		{"1972-201", true, "1972-07-19T00:00:00Z"},
+		{"1972201", true, "1972-07-19T00:00:00Z"},
+		{"Wed, 19 Jul 1972 09:30:00 +1000", true, "1972-07-19T09:30:00+10:00"},
+		{"19 Jul 72 09:30 -0800", true, "1972-07-19T09:30:00-08:00"},
+		{"Wed Jul 19 09:30:00 UTC 1972", true, "1972-07-19T09:30:00Z"},
+		{"19/Jul/1972:09:30:00 -0800", true, "1972-07-19T09:30:00-08:00"},
+		{"@80427903", true, "1972-07-19T21:05:03Z"},
+		{"  @-1  ", true, "1969-12-31T23:59:59Z"},
+		{"1700000000", false, "2023-11-14T22:13:20Z"},
+		{"1700000000123", false, "2023-11-14T22:13:20.123Z"},
+		{"19/07/1972", false, "1972-07-19T00:00:00Z"},
+		{"7/19/1972", false, "1972-07-19T00:00:00Z"},
+		{"05/05/2021", false, "2021-05-05T00:00:00Z"},
+		{"19.7.1972", false, "1972-07-19T00:00:00Z"},
+		{"1972/07/19", false, "1972-07-19T00:00:00Z"},
+		{"19 July 1972", false, "1972-07-19T00:00:00Z"},
+		{"Wednesday, 19 July 1972", false, "1972-07-19T00:00:00Z"},
+		{"July 19, 1972", false, "1972-07-19T00:00:00Z"},
+		{"Jul 19 1972", false, "1972-07-19T00:00:00Z"},
+	}
+	for _, tt := range detectTests {
+		actual, err := detectTime(tt.input, time.UTC, tt.strict)
+		if err != nil || actual.Format(time.RFC3339Nano) != tt.output {
+			t.Errorf("detectTime(%q, %v) = %v, %v; expected %s", tt.input, tt.strict, actual, err, tt.output)
+		}
+	}
+}
+
+func TestDetectTimeErrors(t *testing.T) {
+	var errorTests = []struct {
+		input  string
+		strict bool
+		output string
+	}{
+		{"03/04/2021", false, "ambiguous date"},
+		{"19/07/1972", true, "unrecognised date"},
+		{"1700000000", true, "unrecognised date"},
+		{"80427903", false, "unrecognised date"},
+		{"1972-W53-1", false, "unrecognised date"},
+		{"1972-367", false, "unrecognised date"},
+		{"1972-000", false, "unrecognised date"},
+		{"yesterday", false, "unrecognised date"},
+	}
+	for _, tt := range errorTests {
+		_, err := detectTime(tt.input, time.UTC, tt.strict)
+		if err == nil || !strings.HasPrefix(err.Error(), tt.output) {
+			t.Errorf("detectTime(%q, %v) returned %v, expected %s", tt.input, tt.strict, err, tt.output)
+		}
+	}
+}
+
+func TestConvertNamesAmbiguousLine(t *testing.T) {
+	code, _, stderr := runTest(t, "1972-07-19\n03/04/2021\n", "convert")
+	if code != exitFailure || !strings.HasPrefix(stderr, "tqdate convert: line 2: ambiguous date \"03/04/2021\"") {
+		t.Errorf("ambiguous input = %d, %q", code, stderr)
+	}
+	code, stdout, _ := runTest(t, "", "convert", "-short", "-layout", "02/01/2006", "03/04/2021")
+	if code != exitOK || stdout != "05J 52\n" {
+		t.Errorf("ambiguous input with -layout = %d, %q", code, stdout)
+	}
+}
+
+func TestLayoutHasTime(t *testing.T) {
+	var layoutTests = []struct {
+		layout  string
+		hasTime bool
+	}{
+		{"2006-01-02", false},
+		{"02/01/2006", false},
+		{"Mon Jan _2 2006", false},
+		{"2006-01-02 15:04", true},
+		{"3PM 2 Jan 2006", true},
+		{time.RFC3339, true},
+		{"2006-01-02 .000", true},
+	}
+	for _, tt := range layoutTests {
+		if actual := layoutHasTime(tt.layout); actual != tt.hasTime {
+			t.Errorf("layoutHasTime(%q) = %v, expected %v", tt.layout, actual, tt.hasTime)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/ratanvarghese/tqtime"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	weekdayAbbrPattern = `(?:Mon|Tue|Wed|Thu|Fri|Sat|Sun)`
+	monthAbbrPattern   = `(?:Jan|Feb|Mar|Apr|May|Jun|Jul|Aug|Sep|Oct|Nov|Dec)`
+)
+
+//builtinPatterns match timestamps commonly found in logs, in formats recognised by detectTime. Longer forms come first, so that a whole timestamp is matched rather than only its date. Bare Unix timestamps are not matched, since too many other numbers look like them.
+var builtinPatterns = []string{
+	`\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}(?::\d{2}(?:\.\d+)?(?:Z|[+-]\d{2}:?\d{2})?)?`,
+	`\d{2}/` + monthAbbrPattern + `/\d{4}:\d{2}:\d{2}:\d{2} [+-]\d{4}`,
+	weekdayAbbrPattern + `, \d{1,2} ` + monthAbbrPattern + ` \d{4} \d{2}:\d{2}:\d{2} (?:[+-]\d{4}|[A-Z]{3,4})`,
+	weekdayAbbrPattern + ` ` + monthAbbrPattern + ` [ \d]\d \d{2}:\d{2}:\d{2}(?: [A-Z]{3,4})? \d{4}`,
+	`\d{4}-\d{2}-\d{2}`,
+}
+
+//patternList is a flag.Value collecting the regular expressions given with -pattern.
+type patternList []string
+
+func (p *patternList) String() string {
+	return strings.Join(*p, " ")
+}
+
+func (p *patternList) Set(s string) error {
+	if _, err := regexp.Compile(s); err != nil {
+		return err
+	}
+	*p = append(*p, s)
+	return nil
+}
+
+//compilePatterns combines the custom patterns and, if builtin is true, the built-in patterns into one regular expression. Where two patterns match at the same place, the earlier one is used. It returns nil if there are no patterns.
+func compilePatterns(custom []string, builtin bool) *regexp.Regexp {
+	patterns := append([]string(nil), custom...)
+	if builtin {
+		for _, p := range builtinPatterns {
+			//Word boundaries stop the built-in patterns from matching inside longer numbers.
+			patterns = append(patterns, `\b`+p+`\b`)
+		}
+	}
+	if len(patterns) == 0 {
+		return nil
+	}
+	return regexp.MustCompile(`(?:` + strings.Join(patterns, `)|(?:`) + `)`)
+}
+
+//dateFilter rewrites the dates it finds in text.
+type dateFilter struct {
+	pattern *regexp.Regexp
+	layout  string
+	bracket bool
+	convert func(t time.Time) string
+}
+
+//line returns s with each date found by f replaced by its Tranquility equivalent, or followed by it in brackets. Text which matches a pattern but is not a date is left unchanged.
+func (f *dateFilter) line(s string) string {
+	return f.pattern.ReplaceAllStringFunc(s, func(match string) string {
+		t, err := time.ParseInLocation(f.layout, match, time.Local)
+		if f.layout == "" || err != nil {
+			t, err = detectTime(match, time.Local, false)
+		}
+		switch {
+		case err != nil:
+			return match
+		case f.bracket:
+			return match + " [" + f.convert(t) + "]"
+		}
+		return f.convert(t)
+	})
+}
+
+//run copies r to w through f, one line at a time. Each line is flushed as soon as it is written, so that the output of commands such as "tail -f" can be read as it arrives.
+func (f *dateFilter) run(r io.Reader, w *bufio.Writer) error {
+	br := bufio.NewReader(r)
+	for {
+		s, err := br.ReadString('\n')
+		if s != "" {
+			w.WriteString(f.line(s))
+			if ferr := w.Flush(); ferr != nil {
+				return ferr
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func runFilter(e *env, args []string) error {
+	fs := e.flags("filter")
+	short := fs.Bool("short", false, "write the compact format, such as \"28M 3\"")
+	var directives layoutFlag
+	fs.Var(&directives, "format", "write dates in `layout`, with the directives of tqtime.FormatTime such as \"%F %T\"")
+	mode := fs.String("mode", "bracket", "\"bracket\" to keep each date and follow it with the Tranquility date in brackets, or \"replace\" to replace it")
+	var patterns patternList
+	fs.Var(&patterns, "pattern", "regular `expression` matching dates, tried before the built-in patterns; may be repeated")
+	builtin := fs.Bool("builtin", true, "match the built-in patterns for RFC 3339, ISO 8601, RFC 1123, date(1) and Common Log Format timestamps")
+	layout := fs.String("layout", "", "Go reference `layout` to try on each match before detecting its format")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return usageError("filter reads standard input and takes no arguments")
+	}
+	f := dateFilter{layout: *layout}
+	switch *mode {
+	case "bracket":
+		f.bracket = true
+	case "replace":
+	default:
+		return usageError(fmt.Sprintf("unknown mode %q; the modes are bracket and replace", *mode))
+	}
+	if f.pattern = compilePatterns(patterns, *builtin); f.pattern == nil {
+		return usageError("there are no patterns; give one with -pattern or allow the built-in patterns")
+	}
+	f.convert = func(t time.Time) string {
+		if directives != "" {
+			return tqtime.FormatTime(t, string(directives))
+		}
+		return format(tqtime.FromTime(t), *short)
+	}
+	return f.run(e.stdin, e.stdout)
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bufio"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestFilter(t *testing.T) {
+	var filterTests = []struct {
+		args   []string
+		input  string
+		output string
+	}{
+		{
+			[]string{"filter", "-short"},
+			"2021-04-03T10:00:00Z GET / 200\n",
+			"2021-04-03T10:00:00Z [05J 52] GET / 200\n",
+		},
+		{
+			[]string{"filter", "-short"},
+			"2000-02-28T23:30:00-0800 GET / 200\n",
+			"2000-02-28T23:30:00-0800 [27H 31] GET / 200\n",
+		},
+		{
+			[]string{"filter", "-short", "-mode", "replace"},
+			"127.0.0.1 - - [19/Jul/1972:09:30:00 -0800] \"GET /\"\r\nDate: Wed, 19 Jul 1972 09:30:00 GMT\n",
+			"127.0.0.1 - - [28M 3] \"GET /\"\r\nDate: 28M 3\n",
+		},
+		{
+			[]string{"filter"},
+			"built Wed Jul 19 09:30:00 UTC 1972 ok",
+			"built Wed Jul 19 09:30:00 UTC 1972 [Thursday, 28 Mendel, 3 After Tranquility] ok",
+		},
+		{
+			[]string{"filter", "-short"},
+			"id 2021-13-45 and 12021-01-015, from 2000-02-28 to 2000-02-29\n\nend\n",
+			"id 2021-13-45 and 12021-01-015, from 2000-02-28 [27H 31] to 2000-02-29 [ALD 31]\n\nend\n",
+		},
+		{
+			[]string{"filter", "-mode", "replace", "-format", "%F %R"},
+			"at 1972-07-19 09:30 and 1972-07-19T23:15:00+10:00\n",
+			"at 28M 3 09:30 and 28M 3 23:15\n",
+		},
+		{
+			[]string{"filter", "-short", "-builtin=false", "-pattern", `@\d+`},
+			"ts=@80427903 on 1972-07-19\n",
+			"ts=@80427903 [28M 3] on 1972-07-19\n",
+		},
+		{
+			[]string{"filter", "-short", "-mode", "replace", "-pattern", `\d{2}\.\d{2}\.\d{4}`, "-layout", "02.01.2006"},
+			"19.07.1972 and 1972-07-20\n",
+			"28M 3 and ARM 3\n",
+		},
+	}
+	for _, tt := range filterTests {
+		code, stdout, stderr := runTest(t, tt.input, tt.args...)
+		if code != exitOK || stdout != tt.output {
+			t.Errorf("tqdate %q with %q = %d, %q, %q; expected %q", tt.args, tt.input, code, stdout, stderr, tt.output)
+		}
+	}
+}
+
+func TestFilterUsage(t *testing.T) {
+	var usageTests = [][]string{
+		{"filter", "-mode", "annotate"},
+		{"filter", "-builtin=false"},
+		{"filter", "-pattern", "("},
+		{"filter", "input.log"},
+	}
+	for _, args := range usageTests {
+		if code, _, _ := runTest(t, "", args...); code != exitUsage {
+			t.Errorf("tqdate %q exited with %d, expected %d", args, code, exitUsage)
+		}
+	}
+}
+
+func TestFilterFlushesEachLine(t *testing.T) {
+	r, w := io.Pipe()
+	out, sink := io.Pipe()
+	f := dateFilter{
+		pattern: compilePatterns(nil, true),
+		bracket: true,
+		convert: func(time.Time) string { return "x" },
+	}
+	go f.run(r, bufio.NewWriter(sink))
+	lines := bufio.NewReader(out)
+	var flushTests = []struct {
+		input  string
+		output string
+	}{
+		{"first 2021-04-03\n", "first 2021-04-03 [x]\n"},
+		{"second\n", "second\n"},
+	}
+	for _, tt := range flushTests {
+		w.Write([]byte(tt.input))
+		if line, err := lines.ReadString('\n'); err != nil || line != tt.output {
+			t.Errorf("read %q, %v while the input was still open; expected %q", line, err, tt.output)
+		}
+	}
+	w.Close()
+}
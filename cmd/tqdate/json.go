@@ -0,0 +1,340 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ratanvarghese/tqtime"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//jsonNode is a JSON value which keeps the order of object members, so that documents can be rewritten without reordering them. Objects have kind '{' and arrays kind '[', with the member names in keys. Other values have kind 0, and scalar holds a string, json.Number, bool or nil. YAML documents are read into the same nodes, with text holding a scalar as it was written on a single line, so that it is written back unchanged.
+type jsonNode struct {
+	kind   json.Delim
+	keys   []string
+	values []*jsonNode
+	scalar interface{}
+	text   string
+}
+
+//decodeJSON reads the next JSON value from dec, which must have UseNumber set so that numbers are written back as they were.
+func decodeJSON(dec *json.Decoder) (*jsonNode, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		return &jsonNode{scalar: tok}, nil
+	}
+	n := &jsonNode{kind: delim}
+	for dec.More() {
+		if delim == '{' {
+			if tok, err = dec.Token(); err != nil {
+				return nil, unexpectedEOF(err)
+			}
+			n.keys = append(n.keys, tok.(string))
+		}
+		v, err := decodeJSON(dec)
+		if err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		n.values = append(n.values, v)
+	}
+	//The closing delimiter.
+	if _, err := dec.Token(); err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	return n, nil
+}
+
+//unexpectedEOF turns io.EOF into io.ErrUnexpectedEOF, for input which ends inside a value.
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+//encode writes n to w. With an empty indent the output is compact, and otherwise each member is on its own line, indented by indent for each level of depth.
+func (n *jsonNode) encode(w *bufio.Writer, indent string, depth int) {
+	if n.kind == 0 {
+		w.Write(marshalScalar(n.scalar))
+		return
+	}
+	end := byte('}')
+	if n.kind == '[' {
+		end = ']'
+	}
+	w.WriteByte(byte(n.kind))
+	for i, v := range n.values {
+		if i > 0 {
+			w.WriteByte(',')
+		}
+		if indent != "" {
+			w.WriteByte('\n')
+			w.WriteString(strings.Repeat(indent, depth+1))
+		}
+		if n.kind == '{' {
+			w.Write(marshalScalar(n.keys[i]))
+			w.WriteByte(':')
+			if indent != "" {
+				w.WriteByte(' ')
+			}
+		}
+		v.encode(w, indent, depth+1)
+	}
+	if indent != "" && len(n.values) > 0 {
+		w.WriteByte('\n')
+		w.WriteString(strings.Repeat(indent, depth))
+	}
+	w.WriteByte(end)
+}
+
+//marshalScalar returns the JSON text of a string, json.Number, bool or nil. Unlike json.Marshal, it does not escape HTML characters, which do not need escaping in a document.
+func marshalScalar(v interface{}) []byte {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.Encode(v)
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+}
+
+//stringList is a flag.Value collecting the values of a repeated flag.
+type stringList []string
+
+func (s *stringList) String() string {
+	return strings.Join(*s, " ")
+}
+
+func (s *stringList) Set(v string) error {
+	*s = append(*s, v)
+	return nil
+}
+
+//jsonTransformer converts the dates in a JSON document.
+type jsonTransformer struct {
+	paths   [][]string
+	suffix  string
+	loc     *time.Location
+	convert func(t time.Time) string
+}
+
+//matchPath reports whether path matches pattern, where "*" in pattern matches any single member name or array index.
+func matchPath(pattern, path []string) bool {
+	if len(pattern) != len(path) {
+		return false
+	}
+	for i := range pattern {
+		if pattern[i] != "*" && pattern[i] != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+//date returns the Tranquility text for the value v found at path, and whether v is to be converted. Without paths, any string which is an RFC 3339 timestamp is converted. At a configured path, v must be a string in a format recognised by detectTime.
+func (j *jsonTransformer) date(v *jsonNode, path []string) (string, bool, error) {
+	s, isString := v.scalar.(string)
+	if len(j.paths) == 0 {
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if !isString || err != nil {
+			return "", false, nil
+		}
+		return j.convert(t), true, nil
+	}
+	for _, p := range j.paths {
+		if !matchPath(p, path) {
+			continue
+		}
+		if !isString {
+			return "", false, fmt.Errorf("at %s: expected a date, found %s", strings.Join(path, "."), marshalScalar(v.scalar))
+		}
+		t, err := detectTime(s, j.loc, false)
+		if err != nil {
+			return "", false, fmt.Errorf("at %s: %v", strings.Join(path, "."), err)
+		}
+		return j.convert(t), true, nil
+	}
+	return "", false, nil
+}
+
+//document converts the dates in doc, which is a whole document. A document which is a single date has no name, so as with a date directly inside an array, it is replaced unless j.suffix is set.
+func (j *jsonTransformer) document(doc *jsonNode) (*jsonNode, error) {
+	if doc.kind != 0 {
+		return doc, j.transform(doc, nil)
+	}
+	s, ok, err := j.date(doc, nil)
+	if err != nil || !ok || j.suffix != "" {
+		return doc, err
+	}
+	return &jsonNode{scalar: s}, nil
+}
+
+//splitPath splits a path given with -path into member names at each dot. A dot which is part of a name is written as "\.", and a backslash as "\\".
+func splitPath(p string) []string {
+	var names []string
+	var name []byte
+	for i := 0; i < len(p); i++ {
+		switch {
+		case p[i] == '\\' && i+1 < len(p) && (p[i+1] == '.' || p[i+1] == '\\'):
+			i++
+			name = append(name, p[i])
+		case p[i] == '.':
+			names = append(names, string(name))
+			name = name[:0]
+		default:
+			name = append(name, p[i])
+		}
+	}
+	return append(names, string(name))
+}
+
+//transform converts the dates in n, which is found at path. Dates are replaced unless j.suffix is set, in which case the Tranquility date is added to the same object under the name of the date followed by the suffix, replacing any member already there. Dates directly inside arrays have no name, so with a suffix they are left as they are.
+func (j *jsonTransformer) transform(n *jsonNode, path []string) error {
+	var keys []string
+	var values []*jsonNode
+	var generated []bool
+	added := make(map[string]bool)
+	for i, v := range n.values {
+		name := strconv.Itoa(i)
+		if n.kind == '{' {
+			name = n.keys[i]
+			keys = append(keys, name)
+		}
+		values = append(values, v)
+		generated = append(generated, false)
+		p := append(path[:len(path):len(path)], name)
+		if v.kind != 0 {
+			if err := j.transform(v, p); err != nil {
+				return err
+			}
+			continue
+		}
+		s, ok, err := j.date(v, p)
+		switch {
+		case err != nil:
+			return err
+		case !ok:
+		case j.suffix == "":
+			values[len(values)-1] = &jsonNode{scalar: s}
+		case n.kind == '{':
+			keys = append(keys, name+j.suffix)
+			values = append(values, &jsonNode{scalar: s})
+			generated = append(generated, true)
+			added[name+j.suffix] = true
+		}
+	}
+	if n.kind == '[' {
+		n.values = values
+		return nil
+	}
+	//Members added by an earlier run are replaced by those added now.
+	n.keys, n.values = nil, nil
+	for i, k := range keys {
+		if generated[i] || !added[k] {
+			n.keys = append(n.keys, k)
+			n.values = append(n.values, values[i])
+		}
+	}
+	return nil
+}
+
+func runJSON(e *env, args []string) error {
+	return runDocuments(e, "json", args)
+}
+
+func runYAML(e *env, args []string) error {
+	return runDocuments(e, "yaml", args)
+}
+
+//runDocuments runs the json command, or the yaml command if name is "yaml". They differ only in how documents are read and written.
+func runDocuments(e *env, name string, args []string) error {
+	fs := e.flags(name)
+	var paths stringList
+	fs.Var(&paths, "path", "dot-separated `path` of dates to convert, such as \"orders.*.created\", where * matches any name or index and \\. is a dot within a name; may be repeated. Without -path, every RFC 3339 timestamp is converted")
+	short := fs.Bool("short", false, "write the compact format, such as \"28M 3\"")
+	var directives layoutFlag
+	fs.Var(&directives, "format", "write dates in `layout`, with the directives of tqtime.FormatTime such as \"%F %T\"")
+	suffix := fs.String("suffix", "", "keep each date and add the Tranquility date beside it, under its name followed by `suffix`, such as \"_tq\"")
+	indent := fs.String("indent", "  ", "`string` used to indent the output; empty for compact output")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	switch {
+	case fs.NArg() > 1:
+		return usageError(name + " reads one file")
+	case name == "yaml" && strings.Trim(*indent, " ") != "":
+		return usageError("YAML can only be indented with spaces")
+	}
+	j := jsonTransformer{suffix: *suffix, loc: time.Local}
+	for _, p := range paths {
+		j.paths = append(j.paths, splitPath(p))
+	}
+	j.convert = func(t time.Time) string {
+		if directives != "" {
+			return tqtime.FormatTime(t, string(directives))
+		}
+		return format(tqtime.FromTime(t), *short)
+	}
+
+	in := e.stdin
+	if fs.NArg() == 1 {
+		f, err := os.Open(fs.Arg(0))
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+	//A stream of several documents, such as JSON Lines or YAML documents separated by "---", is transformed one document at a time.
+	var next func() (*jsonNode, error)
+	write := func(doc *jsonNode, n int) {
+		doc.encode(e.stdout, *indent, 0)
+		e.stdout.WriteByte('\n')
+	}
+	if name == "yaml" {
+		dec, err := newYAMLDecoder(in)
+		if err != nil {
+			return err
+		}
+		next = dec.next
+		write = func(doc *jsonNode, n int) {
+			if n > 1 {
+				e.stdout.WriteString("---\n")
+			}
+			doc.encodeYAML(e.stdout, *indent)
+		}
+	} else {
+		dec := json.NewDecoder(in)
+		dec.UseNumber()
+		next = func() (*jsonNode, error) { return decodeJSON(dec) }
+	}
+	for n := 1; ; n++ {
+		doc, err := next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return jsonError(n, err)
+		}
+		if doc, err = j.document(doc); err != nil {
+			return jsonError(n, err)
+		}
+		write(doc, n)
+	}
+}
+
+//jsonError adds the number of the document to err, which is the position of the problem when the input holds several documents.
+func jsonError(n int, err error) error {
+	if err == io.ErrUnexpectedEOF {
+		err = errors.New("unexpected end of JSON input")
+	}
+	return fmt.Errorf("document %d: %v", n, err)
+}
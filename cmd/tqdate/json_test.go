@@ -0,0 +1,107 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestJSON(t *testing.T) {
+	var jsonTests = []struct {
+		args   []string
+		input  string
+		output string
+	}{
+		{
+			//Without paths, only RFC 3339 timestamps are converted, and everything else is written as it was.
+			[]string{"json", "-short", "-indent", ""},
+			`{"z":1.50,"id":"a<b","at":"1972-07-19T09:30:00Z","on":"19/07/1972","tags":["2000-02-29T00:00:00Z",null,true],"e":{}}`,
+			`{"z":1.50,"id":"a<b","at":"28M 3","on":"19/07/1972","tags":["ALD 31",null,true],"e":{}}` + "\n",
+		},
+		{
+			[]string{"json", "-short"},
+			`{"at": "2000-02-29T00:00:00Z", "n": [1, 2], "e": []}`,
+			"{\n  \"at\": \"ALD 31\",\n  \"n\": [\n    1,\n    2\n  ],\n  \"e\": []\n}\n",
+		},
+		{
+			[]string{"json", "-short", "-indent", "", "-path", "orders.*.when", "-path", "created"},
+			`{"created":"1972-07-19","orders":[{"when":"19/07/1972","n":1e3},{"when":"@0"}],"when":"1972-07-19"}`,
+			`{"created":"28M 3","orders":[{"when":"28M 3","n":1e3},{"when":"25F 1"}],"when":"1972-07-19"}` + "\n",
+		},
+		{
+			//Siblings are added after each date, and replace those added before.
+			[]string{"json", "-short", "-indent", "", "-suffix", "_tq"},
+			`{"a":"1972-07-19T09:30:00Z","a_tq":"old","b":["2000-02-29T00:00:00Z"]}`,
+			`{"a":"1972-07-19T09:30:00Z","a_tq":"28M 3","b":["2000-02-29T00:00:00Z"]}` + "\n",
+		},
+		{
+			[]string{"json", "-indent", "", "-suffix", "_tq", "-format", "%F"},
+			`{"a_tq":"old","a":"1972-07-19T09:30:00Z"}`,
+			`{"a":"1972-07-19T09:30:00Z","a_tq":"28M 3"}` + "\n",
+		},
+		{
+			//JSON Lines are converted one document at a time.
+			[]string{"json", "-short", "-indent", ""},
+			"{\"a\":\"1969-07-20T00:00:00Z\"}\n\"2000-02-29T00:00:00Z\"\n[]\n1\n",
+			"{\"a\":\"MNL 0\"}\n\"ALD 31\"\n[]\n1\n",
+		},
+		{
+			//A document which is a single date has no name to add a suffix to.
+			[]string{"json", "-short", "-suffix", "_tq"},
+			"\"2000-02-29T00:00:00Z\"",
+			"\"2000-02-29T00:00:00Z\"\n",
+		},
+		{
+			[]string{"json", "-short", "-indent", "", "-path", `meta.created\.at`, "-path", `a\\b`},
+			`{"meta":{"created.at":"2000-02-29","created":{"at":"2000-02-29"}},"a\\b":"1969-07-20"}`,
+			`{"meta":{"created.at":"ALD 31","created":{"at":"2000-02-29"}},"a\\b":"MNL 0"}` + "\n",
+		},
+	}
+	for _, tt := range jsonTests {
+		code, stdout, stderr := runTest(t, tt.input, tt.args...)
+		if code != exitOK || stdout != tt.output {
+			t.Errorf("tqdate %q = %d, %q, %q; expected %q", tt.args, code, stdout, stderr, tt.output)
+		}
+	}
+}
+
+func TestSplitPath(t *testing.T) {
+	var pathTests = []struct {
+		input  string
+		output []string
+	}{
+		{"a", []string{"a"}},
+		{"orders.*.created", []string{"orders", "*", "created"}},
+		{`meta.created\.at`, []string{"meta", "created.at"}},
+		{`a\\.b`, []string{`a\`, "b"}},
+		{`a\b`, []string{`a\b`}},
+		{"a..b", []string{"a", "", "b"}},
+	}
+	for _, tt := range pathTests {
+		if actual := splitPath(tt.input); strings.Join(actual, "|") != strings.Join(tt.output, "|") || len(actual) != len(tt.output) {
+			t.Errorf("splitPath(%q) = %q; expected %q", tt.input, actual, tt.output)
+		}
+	}
+}
+
+func TestJSONErrors(t *testing.T) {
+	var errorTests = []struct {
+		args  []string
+		input string
+		code  int
+		err   string
+	}{
+		{[]string{"json", "-path", "a"}, `{"a":1}`, exitFailure, "document 1: at a: expected a date, found 1"},
+		{[]string{"json", "-path", "a.*"}, `{"a":["soon"]}`, exitFailure, "document 1: at a.0: unrecognised date"},
+		{[]string{"json"}, "{}\n{\"a\":", exitFailure, "document 2: unexpected end of JSON input"},
+		{[]string{"json"}, "[1,", exitFailure, "document 1: unexpected end of JSON input"},
+		{[]string{"json"}, `{"a" 1}`, exitFailure, "document 1: invalid character"},
+		{[]string{"json", "a.json", "b.json"}, "", exitUsage, "json reads one file"},
+		{[]string{"json", "no-such-file.json"}, "", exitFailure, "no-such-file.json"},
+	}
+	for _, tt := range errorTests {
+		code, _, stderr := runTest(t, tt.input, tt.args...)
+		if code != tt.code || !strings.Contains(stderr, tt.err) {
+			t.Errorf("tqdate %q = %d, %q; expected %d and an error containing %q", tt.args, code, stderr, tt.code, tt.err)
+		}
+	}
+}
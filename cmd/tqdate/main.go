@@ -0,0 +1,273 @@
+//Command tqdate converts dates between the Gregorian and Tranquility calendars.
+//
+//Usage:
+//
+//	tqdate [-u] [-d STRING | -r FILE] [-I[TIMESPEC] | +FORMAT]
+//	tqdate <command> [arguments]
+//
+//Without a command, tqdate prints the current date like date(1), and understands the same options -d, -r, -u and -I. FORMAT uses the directives of tqtime.FormatTime, so shell scripts can switch from date(1) to tqdate with few changes.
+//
+//The commands are:
+//
+//	date      print a date in the style of date(1)
+//	convert   convert Gregorian dates to Tranquility dates
+//	reverse   convert Tranquility dates to Gregorian dates
+//	today     print the current Tranquility date
+//	next      list the upcoming special days
+//	diff      count the days between two dates
+//	range     list every day of a range of dates
+//	filter    rewrite the dates found in text
+//	csv       convert the date columns of a CSV file
+//	json      convert the dates in a JSON document
+//	yaml      convert the dates in a YAML document
+//	watch     print the date now and at each midnight
+//
+//Run "tqdate help <command>" or "tqdate <command> -help" for the arguments of a command. Commands which convert dates read them from their arguments, or from standard input, one per line, if there are no arguments. The current time is taken from the TQ_NOW environment variable if it is set, as described for tqtime.ClockFromEnv.
+//
+//The convert command detects the format of each Gregorian date unless it is given one with -layout. It accepts RFC 3339, ISO 8601 calendar, week and ordinal dates, RFC 1123, RFC 822, the output of date(1), Unix seconds written as @SECONDS, and, unless -strict is given, bare Unix timestamps in seconds or milliseconds and common local forms such as "19/07/1972", "19 July 1972" and "July 19, 1972". A date such as "03/04/2021", which could be day/month/year or month/day/year, is reported as ambiguous.
+//
+//The Tranquility day of a Gregorian time is decided by one time zone, whose midnight starts the day. For convert this is the zone given with -tz, so that the same instant always has the same date, whatever offset it is written with. Without -tz it is the zone of each time: its own offset if it gives one, and otherwise the zone given with -input-tz, which is local time by default. A date without a time of day, such as "1972-07-19", names a whole day rather than an instant, so it keeps its day in every zone. Giving -tz more than once writes the date in each zone, separated by tabs. The today, next and watch commands take -tz for the zone whose day is current, and today lists the date in each zone if given several. The other commands use local time, which can be changed with the TZ environment variable.
+//
+//The convert and reverse commands write structured records with -output json, csv or tsv. Each record holds the input, the Gregorian date, the Tranquility year, month number and name, day, weekday, special day code and era, and the short and long formats. json writes one object per line, and csv and tsv start with a header.
+//
+//By default convert and reverse stop at the first input which cannot be converted. With -skip-errors they convert every input, reporting each failure on standard error with its line or argument number, and with -inline-errors they write each failure to the output in its place, so that every input has a line of output. Either way the exit status is 1 if any input failed, and a count of the failures is reported at the end.
+//
+//The filter command copies standard input to standard output, and finds RFC 3339, ISO 8601, RFC 1123, date(1) and Common Log Format timestamps anywhere in each line. By default each is followed by its Tranquility date in brackets, and with -mode replace each is replaced by it. Other formats can be matched with -pattern. Each line is written as soon as it is read, so filter can follow "tail -f".
+//
+//The csv command converts the columns given with -column, either appending a Tranquility column for each or replacing the dates. Each column may have its own layout and time zone, as in -column "Posted|02/01/2006|Australia/Brisbane". The file is read one row at a time, so large files use little memory. A cell which cannot be converted is reported with its row and column, and stops the command unless -skip-errors is given.
+//
+//The json command converts the values at the paths given with -path, such as "orders.*.created", where a dot within a member name is written as "\.", or every RFC 3339 timestamp if no paths are given. The dates are replaced, or with -suffix the Tranquility date is added beside each one. The order of members is kept, and a stream of documents such as JSON Lines is converted one document at a time. The yaml command does the same for YAML documents, with the same flags. It reads the YAML found in configuration files, including streams of documents separated by "---", but not anchors, aliases or tags. Comments are dropped, and the document is written in block style, with unchanged scalars written as they were.
+//
+//The watch command prints the current date, and then prints it again at each midnight, for status bars such as tmux, polybar and i3bar. It sleeps until the next midnight found by tqtime.NextBoundary rather than checking the time every second, waking every 15 minutes at most so that the date is soon right again after the computer resumes from suspend or its clock is changed. Each date is flushed as it is written. With -i3bar it writes the JSON protocol of i3bar and swaybar, with the compact format as the short text of the block.
+//
+//The exit status is 0 on success, 1 if a date could not be read or converted, and 2 if the command line is wrong.
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/ratanvarghese/tqtime"
+	"io"
+	"os"
+	"strings"
+)
+
+//The exit statuses of tqdate.
+const (
+	exitOK      = 0
+	exitFailure = 1
+	exitUsage   = 2
+)
+
+//env holds the streams and clock of one run of tqdate, so that tests can run commands without starting a process.
+type env struct {
+	stdin  io.Reader
+	stdout *bufio.Writer
+	stderr io.Writer
+	clock  tqtime.Clock
+}
+
+//command is a subcommand of tqdate. run parses its own flags from args.
+type command struct {
+	name    string
+	args    string
+	summary string
+	run     func(e *env, args []string) error
+}
+
+var commands []command
+
+func init() {
+	//commands is filled in here, because the help command refers back to it.
+	commands = []command{
+		{"date", "[-u] [-d STRING | -r FILE] [-I[TIMESPEC] | +FORMAT]", "print a date in the style of date(1)", runDate},
+		{"convert", "[-short] [-strict | -layout LAYOUT] [-tz ZONE]... [-input-tz ZONE] [-output FORMAT] [-skip-errors | -inline-errors] [DATE...]", "convert Gregorian dates to Tranquility dates", runConvert},
+		{"reverse", "[-layout LAYOUT | -output FORMAT] [-skip-errors | -inline-errors] [DATE...]", "convert Tranquility dates to Gregorian dates", runReverse},
+		{"today", "[-short] [-tz ZONE]...", "print the current Tranquility date", runToday},
+		{"next", "[-short] [-count N] [-from DATE] [-tz ZONE]", "list the upcoming special days", runNext},
+		{"diff", "DATE DATE", "count the days between two dates", runDiff},
+		{"range", "[-short] RANGE | FIRST LAST", "list every day of a range of dates", runRange},
+		{"filter", "[-short | -format FORMAT] [-mode bracket|replace] [-pattern REGEXP]... [-builtin=false] [-layout LAYOUT]", "rewrite the dates found in text", runFilter},
+		{"csv", "-column COLUMN[|LAYOUT[|ZONE]]... [-short | -format FORMAT] [-mode append|replace] [-skip-errors] [FILE]", "convert the date columns of a CSV file", runCSV},
+		{"json", "[-path PATH]... [-short | -format FORMAT] [-suffix SUFFIX] [-indent STRING] [FILE]", "convert the dates in a JSON document", runJSON},
+		{"yaml", "[-path PATH]... [-short | -format FORMAT] [-suffix SUFFIX] [-indent STRING] [FILE]", "convert the dates in a YAML document", runYAML},
+		{"watch", "[-short | -format FORMAT] [-tz ZONE] [-i3bar] [-count N]", "print the date now and at each midnight", runWatch},
+		{"help", "[COMMAND]", "describe a command", runHelp},
+	}
+}
+
+func lookup(name string) *command {
+	for i := range commands {
+		if commands[i].name == name {
+			return &commands[i]
+		}
+	}
+	return nil
+}
+
+//errUsage is returned by commands when the command line is wrong and the problem has already been reported.
+var errUsage = errors.New("usage")
+
+//usageError is returned by commands when the command line is wrong. The message is reported along with the usage of the command.
+type usageError string
+
+func (u usageError) Error() string {
+	return string(u)
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+//run runs tqdate with the command line arguments args, not including the program name, and returns the exit status.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	clock, err := tqtime.ClockFromEnv()
+	if err != nil {
+		fmt.Fprintln(stderr, "tqdate:", err)
+		return exitFailure
+	}
+	e := &env{stdin: stdin, stdout: bufio.NewWriter(stdout), stderr: stderr, clock: clock}
+
+	switch {
+	case len(args) == 0:
+		args = []string{"date"}
+	case args[0] == "-h" || args[0] == "-help" || args[0] == "--help":
+		args = []string{"help"}
+	case strings.HasPrefix(args[0], "-") || strings.HasPrefix(args[0], "+"):
+		//Options without a command are those of date(1).
+		args = append([]string{"date"}, args...)
+	}
+	cmd := lookup(args[0])
+	if cmd == nil {
+		fmt.Fprintf(stderr, "tqdate: unknown command %q\nRun 'tqdate help' for usage.\n", args[0])
+		return exitUsage
+	}
+	err = cmd.run(e, args[1:])
+	//Output smaller than the buffer is only written here, so an error writing it must still fail the command.
+	if ferr := e.stdout.Flush(); ferr != nil && err == nil {
+		err = ferr
+	}
+	return e.exit(cmd, err)
+}
+
+//exit reports the error returned by cmd and returns the matching exit status.
+func (e *env) exit(cmd *command, err error) int {
+	switch err.(type) {
+	case nil:
+		return exitOK
+	case usageError:
+		fmt.Fprintf(e.stderr, "tqdate %s: %v\n", cmd.name, err)
+		fmt.Fprintf(e.stderr, "usage: tqdate %s %s\n", cmd.name, cmd.args)
+		return exitUsage
+	}
+	switch err {
+	case flag.ErrHelp:
+		return exitOK
+	case errUsage:
+		return exitUsage
+	}
+	fmt.Fprintf(e.stderr, "tqdate %s: %v\n", cmd.name, err)
+	return exitFailure
+}
+
+//usage writes the list of commands to standard error.
+func (e *env) usage() {
+	fmt.Fprintf(e.stderr, "Usage:\n\n\ttqdate %s\n\ttqdate <command> [arguments]\n\nThe commands are:\n\n", lookup("date").args)
+	for _, c := range commands {
+		fmt.Fprintf(e.stderr, "\t%-9s %s\n", c.name, c.summary)
+	}
+	fmt.Fprintf(e.stderr, "\nRun 'tqdate help <command>' for more about a command.\n")
+}
+
+//flags returns the flag set of the named command, which writes its messages to standard error.
+func (e *env) flags(name string) *flag.FlagSet {
+	cmd := lookup(name)
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(e.stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(e.stderr, "usage: tqdate %s %s\n\n%s.\n", cmd.name, cmd.args, strings.ToUpper(cmd.summary[:1])+cmd.summary[1:])
+		var hasFlags bool
+		fs.VisitAll(func(*flag.Flag) { hasFlags = true })
+		if hasFlags {
+			fmt.Fprintf(e.stderr, "\nFlags:\n")
+			fs.PrintDefaults()
+		}
+	}
+	return fs
+}
+
+//parse parses args with fs, and converts the errors of the flag package into those understood by exit.
+func parse(fs *flag.FlagSet, args []string) error {
+	switch err := fs.Parse(args); err {
+	case nil, flag.ErrHelp:
+		return err
+	}
+	return errUsage
+}
+
+//outputError is returned by the functions passed to eachInput when the output cannot be written. Unlike an input which cannot be converted, it stops eachInput at once, whatever onError does.
+type outputError struct {
+	err error
+}
+
+func (o outputError) Error() string {
+	return o.err.Error()
+}
+
+//eachInput calls fn with each input of a command: the arguments if there are any, and otherwise each non-blank line of standard input with surrounding spaces removed, including a last line without a newline. An error from fn is given the position of the input and passed to onError, along with the input, and the loop stops if onError returns an error. With a nil onError the first error stops the loop and is returned. An outputError from fn is returned at once, without its position. If onError let the loop carry on past any errors, eachInput returns a summary of them after the last input.
+func (e *env) eachInput(args []string, fn func(s string) error, onError func(s string, err error) error) error {
+	var count, failed int
+	each := func(s, pos string) error {
+		count++
+		err := fn(s)
+		if err == nil {
+			return nil
+		}
+		if o, ok := err.(outputError); ok {
+			return o.err
+		}
+		failed++
+		err = fmt.Errorf("%s: %v", pos, err)
+		if onError == nil {
+			return err
+		}
+		return onError(s, err)
+	}
+	if len(args) > 0 {
+		for i, a := range args {
+			if err := each(a, fmt.Sprintf("argument %d", i+1)); err != nil {
+				return err
+			}
+		}
+	} else {
+		br := bufio.NewReader(e.stdin)
+		for n := 1; ; n++ {
+			line, err := br.ReadString('\n')
+			if s := strings.TrimSpace(line); s != "" {
+				if err := each(s, fmt.Sprintf("line %d", n)); err != nil {
+					return err
+				}
+			}
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d inputs could not be converted", failed, count)
+	}
+	return nil
+}
+
+//skipErrors returns an onError function for eachInput, which reports each error on standard error and carries on.
+func (e *env) skipErrors(name string) func(s string, err error) error {
+	return func(s string, err error) error {
+		fmt.Fprintf(e.stderr, "tqdate %s: %v\n", name, err)
+		return nil
+	}
+}
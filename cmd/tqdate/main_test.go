@@ -0,0 +1,178 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+//runTest runs tqdate with args and the given standard input, and returns the exit status and output.
+func runTest(t *testing.T, stdin string, args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(args, strings.NewReader(stdin), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestMain(m *testing.M) {
+	os.Setenv("TQ_NOW", "2000-02-29")
+	os.Exit(m.Run())
+}
+
+func TestExitStatus(t *testing.T) {
+	var exitTests = []struct {
+		args []string
+		code int
+	}{
+		{nil, exitOK},
+		{[]string{"frob"}, exitUsage},
+		{[]string{"help"}, exitOK},
+		{[]string{"--help"}, exitOK},
+		{[]string{"help", "convert"}, exitOK},
+		{[]string{"help", "frob"}, exitUsage},
+		{[]string{"convert", "-help"}, exitOK},
+		{[]string{"diff", "--help"}, exitOK},
+		{[]string{"convert", "-bogus"}, exitUsage},
+		{[]string{"today", "extra"}, exitUsage},
+		{[]string{"diff", "01A 1"}, exitUsage},
+		{[]string{"next", "-count", "-1"}, exitUsage},
+		{[]string{"convert", "1969-07-20"}, exitOK},
+		{[]string{"convert", "not a date"}, exitFailure},
+		{[]string{"convert", "-skip-errors", "-inline-errors", "1969-07-20"}, exitUsage},
+		{[]string{"reverse", "-skip-errors", "-inline-errors", "MNL 0"}, exitUsage},
+		{[]string{"reverse", "-layout", "Jan 2 2006", "-output", "json", "MNL 0"}, exitUsage},
+		{[]string{"reverse", "-layout", "Jan 2 2006", "-output", "text", "MNL 0"}, exitOK},
+		{[]string{"reverse", "29M 3"}, exitFailure},
+		{[]string{"watch", "-format", "%-d %q"}, exitUsage},
+		{[]string{"filter", "-format", "%V"}, exitUsage},
+		{[]string{"range", "28C 55–01A 55"}, exitFailure},
+	}
+	for _, tt := range exitTests {
+		code, _, _ := runTest(t, "", tt.args...)
+		if code != tt.code {
+			t.Errorf("tqdate %q exited with %d, expected %d", tt.args, code, tt.code)
+		}
+	}
+}
+
+func TestHelpMentionsFlags(t *testing.T) {
+	_, _, stderr := runTest(t, "", "help", "next")
+	for _, s := range []string{"usage: tqdate next", "-count", "-from", "-short"} {
+		if !strings.Contains(stderr, s) {
+			t.Errorf("help for next does not mention %q:\n%s", s, stderr)
+		}
+	}
+}
+
+func TestErrorNamesLine(t *testing.T) {
+	code, stdout, stderr := runTest(t, "1969-07-20\n\n1969-07-21\nbad\n1969-07-22\n", "convert", "-short")
+	if code != exitFailure {
+		t.Errorf("exited with %d, expected %d", code, exitFailure)
+	}
+	if stdout != "MNL 0\n01A 1\n" {
+		t.Errorf("output before the error was %q", stdout)
+	}
+	if !strings.HasPrefix(stderr, "tqdate convert: line 4: ") {
+		t.Errorf("error did not name the line: %q", stderr)
+	}
+}
+
+func TestBatchErrors(t *testing.T) {
+	//The last line has no newline, and must not be dropped.
+	const input = "1969-07-20\nbad\n\n1969-07-21\n03/04/2021\n1969-07-22"
+	var batchTests = []struct {
+		args   []string
+		output string
+		errors []string
+	}{
+		{
+			[]string{"convert", "-short", "-skip-errors"},
+			"MNL 0\n01A 1\n02A 1\n",
+			[]string{"line 2: unrecognised date", "line 5: ambiguous date", "2 of 5 inputs could not be converted"},
+		},
+		{
+			[]string{"convert", "-short", "-inline-errors"},
+			"MNL 0\nerror: line 2: unrecognised date \"bad\"\n01A 1\nerror: line 5: ambiguous date \"03/04/2021\": as day/month/year it is 2021-04-03, but as month/day/year it is 2021-03-04\n02A 1\n",
+			[]string{"2 of 5 inputs could not be converted"},
+		},
+		{
+			[]string{"convert", "-inline-errors", "-output", "json", "bad"},
+			"{\"input\":\"bad\",\"error\":\"argument 1: unrecognised date \\\"bad\\\"\"}\n",
+			[]string{"1 of 1 inputs could not be converted"},
+		},
+		{
+			[]string{"reverse", "-inline-errors", "-output", "csv", "MNL 0", "29M 3"},
+			"input,gregorian,year,month,monthName,day,weekday,special,era,short,long,error\n" +
+				"MNL 0,1969-07-20,0,0,,0,,MNL,,MNL 0,Moon Landing Day,\n" +
+				"29M 3,,,,,,,,,,,argument 2: tqtime: invalid date\n",
+			[]string{"1 of 2 inputs could not be converted"},
+		},
+		{
+			[]string{"reverse", "-skip-errors", "29M 3", "MNL 0"},
+			"1969-07-20\n",
+			[]string{"argument 1: tqtime: invalid date"},
+		},
+	}
+	for _, tt := range batchTests {
+		code, stdout, stderr := runTest(t, input, tt.args...)
+		if code != exitFailure || stdout != tt.output {
+			t.Errorf("tqdate %q = %d, %q; expected %d, %q", tt.args, code, stdout, exitFailure, tt.output)
+		}
+		for _, s := range tt.errors {
+			if !strings.Contains(stderr, s) {
+				t.Errorf("tqdate %q did not report %q: %q", tt.args, s, stderr)
+			}
+		}
+	}
+}
+
+//errDiskFull is returned by fullWriter.
+var errDiskFull = errors.New("disk full")
+
+//fullWriter is an io.Writer which always fails, like a full disk or a closed pipe.
+type fullWriter struct{}
+
+func (fullWriter) Write(p []byte) (int, error) {
+	return 0, errDiskFull
+}
+
+func TestBatchOutputError(t *testing.T) {
+	input := strings.Repeat("1969-07-20\n", 100) + "bad\n"
+	for _, flag := range []string{"-skip-errors", "-inline-errors"} {
+		var stderr bytes.Buffer
+		e := &env{stdin: strings.NewReader(input), stdout: bufio.NewWriterSize(fullWriter{}, 16), stderr: &stderr}
+		if err := runConvert(e, []string{flag}); err != errDiskFull {
+			t.Errorf("convert %s returned %v; expected %v", flag, err, errDiskFull)
+		}
+		if stderr.Len() != 0 {
+			t.Errorf("convert %s reported the output error as failed inputs: %q", flag, stderr.String())
+		}
+	}
+}
+
+func TestFlushError(t *testing.T) {
+	for _, args := range [][]string{{"convert", "2000-01-01"}, {"today"}, {"convert", "-output", "json", "2000-01-01"}} {
+		var stderr bytes.Buffer
+		code := run(args, strings.NewReader(""), fullWriter{}, &stderr)
+		if code != exitFailure || !strings.Contains(stderr.String(), errDiskFull.Error()) {
+			t.Errorf("tqdate %q to a full disk = %d, %q; expected %d and %q", args, code, stderr.String(), exitFailure, errDiskFull)
+		}
+	}
+}
+
+func TestLastLineWithoutNewline(t *testing.T) {
+	code, stdout, stderr := runTest(t, "1969-07-20\n1969-07-21", "convert", "-short")
+	if code != exitOK || stdout != "MNL 0\n01A 1\n" {
+		t.Errorf("exited with %d after writing %q, %q", code, stdout, stderr)
+	}
+}
+
+func TestBadNowEnv(t *testing.T) {
+	os.Setenv("TQ_NOW", "yesterday")
+	defer os.Setenv("TQ_NOW", "2000-02-29")
+	if code, _, _ := runTest(t, "", "today"); code != exitFailure {
+		t.Errorf("bad TQ_NOW exited with %d, expected %d", code, exitFailure)
+	}
+}
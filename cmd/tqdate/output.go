@@ -0,0 +1,168 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/ratanvarghese/tqtime"
+	"io"
+	"strconv"
+)
+
+//record holds every component of a converted date, for the structured output formats. On special days Month and Day are 0, MonthName and Weekday are empty, and Special holds the code of the day. Era is "AT" After Tranquility, "BT" Before Tranquility, and empty on Moon Landing Day.
+type record struct {
+	Input     string `json:"input"`
+	Gregorian string `json:"gregorian"`
+	Year      int64  `json:"year"`
+	Month     int    `json:"month"`
+	MonthName string `json:"monthName"`
+	Day       int    `json:"day"`
+	Weekday   string `json:"weekday"`
+	Special   string `json:"special"`
+	Era       string `json:"era"`
+	Short     string `json:"short"`
+	Long      string `json:"long"`
+}
+
+//recordHeader names the columns of the csv and tsv formats, in the order written by record.fields.
+var recordHeader = []string{"input", "gregorian", "year", "month", "monthName", "day", "weekday", "special", "era", "short", "long"}
+
+//newRecord returns the components of d, which was read from input and falls on the Gregorian date gregorian, written as YYYY-MM-DD.
+func newRecord(input string, d tqtime.TqDate, gregorian string) record {
+	r := record{
+		Input:     input,
+		Gregorian: gregorian,
+		Year:      d.Year,
+		Short:     d.ShortDate(),
+		Long:      d.LongDate(),
+	}
+	if d.Month == tqtime.SpecialDay {
+		r.Special = tqtime.DayCode(d.Day)
+	} else {
+		r.Month, r.MonthName, r.Day, r.Weekday = int(d.Month), d.Month.String(), d.Day, d.Weekday().String()
+	}
+	switch {
+	case d.Year > 0:
+		r.Era = "AT"
+	case d.Year < 0:
+		r.Era = "BT"
+	}
+	return r
+}
+
+func (r record) fields() []string {
+	return []string{
+		r.Input,
+		r.Gregorian,
+		strconv.FormatInt(r.Year, 10),
+		strconv.Itoa(r.Month),
+		r.MonthName,
+		strconv.Itoa(r.Day),
+		r.Weekday,
+		r.Special,
+		r.Era,
+		r.Short,
+		r.Long,
+	}
+}
+
+//errorRecord is written in place of a record for an input which could not be converted, with -inline-errors.
+type errorRecord struct {
+	Input string `json:"input"`
+	Error string `json:"error"`
+}
+
+//recordWriter writes converted dates in one of the formats chosen with -output. writeError writes an input which could not be converted, so that each input has a line of output. flush must be called after the last record.
+type recordWriter interface {
+	write(r record) error
+	writeError(input string, err error) error
+	flush() error
+}
+
+//outputUsage describes the -output flag.
+const outputUsage = "`format` of the output: text, json (one object per line), csv or tsv"
+
+//newRecordWriter returns a recordWriter for the named format. The text format writes the compact format of ShortDate if short is true, and the descriptive format of LongDate otherwise. The csv and tsv formats start with a header, which has an error column at the end if inlineErrors is true.
+func newRecordWriter(name string, w io.Writer, short, inlineErrors bool) (recordWriter, error) {
+	switch name {
+	case "text":
+		return textWriter{w, short}, nil
+	case "json":
+		return jsonWriter{json.NewEncoder(w)}, nil
+	case "csv", "tsv":
+		cw := csv.NewWriter(w)
+		if name == "tsv" {
+			cw.Comma = '\t'
+		}
+		header := recordHeader
+		if inlineErrors {
+			header = append(header[:len(header):len(header)], "error")
+		}
+		return csvWriter{cw, inlineErrors}, cw.Write(header)
+	}
+	return nil, usageError(fmt.Sprintf("unknown output format %q; the formats are text, json, csv and tsv", name))
+}
+
+type textWriter struct {
+	w     io.Writer
+	short bool
+}
+
+func (t textWriter) write(r record) error {
+	s := r.Long
+	if t.short {
+		s = r.Short
+	}
+	_, err := fmt.Fprintln(t.w, s)
+	return err
+}
+
+func (t textWriter) writeError(input string, err error) error {
+	_, werr := fmt.Fprintf(t.w, "error: %v\n", err)
+	return werr
+}
+
+func (textWriter) flush() error {
+	return nil
+}
+
+type jsonWriter struct {
+	enc *json.Encoder
+}
+
+func (j jsonWriter) write(r record) error {
+	return j.enc.Encode(r)
+}
+
+func (j jsonWriter) writeError(input string, err error) error {
+	return j.enc.Encode(errorRecord{input, err.Error()})
+}
+
+func (jsonWriter) flush() error {
+	return nil
+}
+
+type csvWriter struct {
+	w            *csv.Writer
+	inlineErrors bool
+}
+
+func (c csvWriter) write(r record) error {
+	fields := r.fields()
+	if c.inlineErrors {
+		fields = append(fields, "")
+	}
+	return c.w.Write(fields)
+}
+
+//writeError writes a row with the input, the error in the last column, and the other columns empty.
+func (c csvWriter) writeError(input string, err error) error {
+	fields := make([]string, len(recordHeader)+1)
+	fields[0], fields[len(fields)-1] = input, err.Error()
+	return c.w.Write(fields)
+}
+
+func (c csvWriter) flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestOutputJSON(t *testing.T) {
+	code, stdout, stderr := runTest(t, "1972-07-19\n2000-02-29\n1969-07-19\n", "convert", "-output", "json")
+	if code != exitOK {
+		t.Fatalf("exited with %d: %s", code, stderr)
+	}
+	expected := []record{
+		{"1972-07-19", "1972-07-19", 3, 13, "Mendel", 28, "Thursday", "", "AT", "28M 3", "Thursday, 28 Mendel, 3 After Tranquility"},
+		{"2000-02-29", "2000-02-29", 31, 0, "", 0, "", "ALD", "AT", "ALD 31", "Aldrin Day, 31 After Tranquility"},
+		{"1969-07-19", "1969-07-19", -1, 13, "Mendel", 28, "Thursday", "", "BT", "28M -1", "Thursday, 28 Mendel, 1 Before Tranquility"},
+	}
+	lines := strings.Split(strings.TrimSuffix(stdout, "\n"), "\n")
+	if len(lines) != len(expected) {
+		t.Fatalf("%d lines written, expected %d:\n%s", len(lines), len(expected), stdout)
+	}
+	for i, line := range lines {
+		var actual record
+		if err := json.Unmarshal([]byte(line), &actual); err != nil || actual != expected[i] {
+			t.Errorf("line %d = %+v, %v; expected %+v", i+1, actual, err, expected[i])
+		}
+	}
+}
+
+func TestOutputCSV(t *testing.T) {
+	var csvTests = []struct {
+		args   []string
+		output string
+	}{
+		{
+			[]string{"convert", "-output", "csv", "July 19, 1972"},
+			"input,gregorian,year,month,monthName,day,weekday,special,era,short,long\n" +
+				"\"July 19, 1972\",1972-07-19,3,13,Mendel,28,Thursday,,AT,28M 3,\"Thursday, 28 Mendel, 3 After Tranquility\"\n",
+		},
+		{
+			[]string{"reverse", "-output", "tsv", "MNL 0"},
+			"input\tgregorian\tyear\tmonth\tmonthName\tday\tweekday\tspecial\tera\tshort\tlong\n" +
+				"MNL 0\t1969-07-20\t0\t0\t\t0\t\tMNL\t\tMNL 0\tMoon Landing Day\n",
+		},
+	}
+	for _, tt := range csvTests {
+		code, stdout, stderr := runTest(t, "", tt.args...)
+		if code != exitOK || stdout != tt.output {
+			t.Errorf("tqdate %q = %d, %q, %q; expected %q", tt.args, code, stdout, stderr, tt.output)
+		}
+		r := csv.NewReader(strings.NewReader(stdout))
+		if tt.args[2] == "tsv" {
+			r.Comma = '\t'
+		}
+		if rows, err := r.ReadAll(); err != nil || len(rows) != 2 {
+			t.Errorf("tqdate %q wrote %d rows, %v", tt.args, len(rows), err)
+		}
+	}
+}
+
+func TestOutputFlushedOnError(t *testing.T) {
+	code, stdout, _ := runTest(t, "1972-07-19\nbad\n", "convert", "-output", "csv")
+	if code != exitFailure || strings.Count(stdout, "\n") != 2 {
+		t.Errorf("exited with %d after writing %q; expected the header and one row", code, stdout)
+	}
+	if code, _, _ := runTest(t, "", "convert", "-output", "xml"); code != exitUsage {
+		t.Errorf("unknown output format exited with %d", code)
+	}
+}
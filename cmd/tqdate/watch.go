@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/json"
+	"github.com/ratanvarghese/tqtime"
+	"time"
+)
+
+//i3barHeader starts the output of the i3bar protocol, which continues as an endless JSON array with one status line for each date.
+const i3barHeader = "{\"version\":1}\n[\n"
+
+//i3barBlock is the single block of each status line written for i3bar.
+type i3barBlock struct {
+	Name      string `json:"name"`
+	FullText  string `json:"full_text"`
+	ShortText string `json:"short_text"`
+}
+
+//watcher writes the current Tranquility date each time it changes.
+type watcher struct {
+	e     *env
+	loc   *time.Location
+	i3bar bool
+	text  func(t time.Time) string
+}
+
+//write writes the date of t as the nth line of output, counting from 0, and flushes it so that a status bar reading the output sees it at once.
+func (w *watcher) write(t time.Time, n int) error {
+	out := w.e.stdout
+	if !w.i3bar {
+		out.WriteString(w.text(t))
+		out.WriteByte('\n')
+		return out.Flush()
+	}
+	if n == 0 {
+		out.WriteString(i3barHeader)
+	} else {
+		out.WriteByte(',')
+	}
+	line, err := json.Marshal([]i3barBlock{{"tqdate", w.text(t), tqtime.FromTime(t).ShortDate()}})
+	if err != nil {
+		return err
+	}
+	out.Write(line)
+	out.WriteByte('\n')
+	return out.Flush()
+}
+
+//maxSleep is the longest watch sleeps at a time. Timers do not count time spent suspended, so after a resume the date shown is out of date for at most this long.
+const maxSleep = 15 * time.Minute
+
+//run writes the date at once, and then again at each midnight of w.loc, until count dates have been written. A count of 0 never stops. Rather than checking the time every second, run sleeps until the next midnight found by tqtime.NextBoundary, waking at most maxSleep apart to check that the clock has not moved.
+func (w *watcher) run(count int) error {
+	now := w.e.clock.Now().In(w.loc)
+	for n := 0; count == 0 || n < count; n++ {
+		if n > 0 {
+			next, _, _, err := tqtime.NextBoundary(now, tqtime.DayBoundary)
+			if err != nil {
+				return err
+			}
+			//A timer may end early by the wall clock, if it was set back, so sleep again until midnight has really passed.
+			for now.Before(next) {
+				wait := next.Sub(now)
+				if wait > maxSleep {
+					wait = maxSleep
+				}
+				<-w.e.clock.After(wait)
+				now = w.e.clock.Now().In(w.loc)
+			}
+		}
+		if err := w.write(now, n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func runWatch(e *env, args []string) error {
+	fs := e.flags("watch")
+	short := fs.Bool("short", false, "print the compact format, such as \"28M 3\"")
+	var directives layoutFlag
+	fs.Var(&directives, "format", "print the date in `layout`, with the directives of tqtime.FormatTime such as \"%a %F\"")
+	zone := zoneFlag{time.Local}
+	fs.Var(&zone, "tz", zoneUsage)
+	i3bar := fs.Bool("i3bar", false, "write the JSON protocol of i3bar and swaybar instead of one line for each date")
+	count := fs.Int("count", 0, "stop after `n` dates have been printed; 0 to carry on until killed")
+	if err := parse(fs, args); err != nil {
+		return err
+	}
+	switch {
+	case fs.NArg() > 0:
+		return usageError("watch takes no arguments")
+	case *count < 0:
+		return usageError("the count must not be negative")
+	}
+	w := watcher{e: e, loc: zone.loc, i3bar: *i3bar}
+	w.text = func(t time.Time) string {
+		if directives != "" {
+			return tqtime.FormatTime(t, string(directives))
+		}
+		return format(tqtime.FromTime(t), *short)
+	}
+	return w.run(*count)
+}
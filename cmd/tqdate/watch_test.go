@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"github.com/ratanvarghese/tqtime"
+	"strconv"
+	"testing"
+	"time"
+)
+
+//watchTest runs the watch command with args on a fake clock starting at start. Each time the command waits, the clock is set to the next of wakes, and the command stops after writing a date for each.
+func watchTest(t *testing.T, start time.Time, wakes []time.Time, args ...string) string {
+	var stdout, stderr bytes.Buffer
+	clock := tqtime.NewFakeClock(start)
+	e := &env{stdout: bufio.NewWriter(&stdout), stderr: &stderr, clock: clock}
+	args = append(args, "-count", strconv.Itoa(len(wakes)+1))
+	done := make(chan error)
+	go func() { done <- runWatch(e, args) }()
+	for _, wake := range wakes {
+		clock.BlockUntilWaiting(1)
+		clock.Set(wake)
+	}
+	if err := <-done; err != nil {
+		t.Errorf("watch %q returned %v: %s", args, err, stderr.String())
+	}
+	return stdout.String()
+}
+
+func TestWatch(t *testing.T) {
+	start := time.Date(2000, time.February, 27, 23, 0, 0, 0, time.UTC)
+	var midnights []time.Time
+	for day := 28; day <= 30; day++ {
+		midnights = append(midnights, time.Date(2000, time.February, day, 0, 0, 0, 0, time.UTC))
+	}
+	var watchTests = []struct {
+		args   []string
+		wakes  []time.Time
+		output string
+	}{
+		{[]string{"-short", "-tz", "UTC"}, nil, "26H 31\n"},
+		{[]string{"-short", "-tz", "UTC"}, midnights, "26H 31\n27H 31\nALD 31\n28H 31\n"},
+		{[]string{"-format", "%a %F %H:%M", "-tz", "UTC"}, midnights[:1], "Tue 26H 31 23:00\nWed 27H 31 00:00\n"},
+		//In Brisbane it is already 28 February, and 29 February starts at 14:00 UTC.
+		{[]string{"-short", "-tz", "+10"}, []time.Time{start.Add(15 * time.Hour)}, "27H 31\nALD 31\n"},
+		//If the wait ends days late, as after a suspend, the date written is the current one.
+		{[]string{"-short", "-tz", "UTC"}, []time.Time{start.Add(48 * time.Hour)}, "26H 31\nALD 31\n"},
+		{
+			[]string{"-i3bar", "-short", "-tz", "UTC"},
+			midnights[:2],
+			"{\"version\":1}\n[\n" +
+				"[{\"name\":\"tqdate\",\"full_text\":\"26H 31\",\"short_text\":\"26H 31\"}]\n" +
+				",[{\"name\":\"tqdate\",\"full_text\":\"27H 31\",\"short_text\":\"27H 31\"}]\n" +
+				",[{\"name\":\"tqdate\",\"full_text\":\"ALD 31\",\"short_text\":\"ALD 31\"}]\n",
+		},
+	}
+	for _, tt := range watchTests {
+		if output := watchTest(t, start, tt.wakes, tt.args...); output != tt.output {
+			t.Errorf("watch %q wrote %q, expected %q", tt.args, output, tt.output)
+		}
+	}
+}
+
+//scriptClock is a Clock whose Now returns each of times in turn, repeating the last, and whose After returns at once, recording how long was asked for.
+type scriptClock struct {
+	times []time.Time
+	waits []time.Duration
+}
+
+func (c *scriptClock) Now() time.Time {
+	t := c.times[0]
+	if len(c.times) > 1 {
+		c.times = c.times[1:]
+	}
+	return t
+}
+
+func (c *scriptClock) After(d time.Duration) <-chan time.Time {
+	c.waits = append(c.waits, d)
+	ch := make(chan time.Time, 1)
+	ch <- time.Time{}
+	return ch
+}
+
+func TestWatchSleeps(t *testing.T) {
+	//The first sleep ends with the clock set back, and the second before midnight, so neither may write the new date.
+	clock := &scriptClock{times: []time.Time{
+		time.Date(2000, time.February, 27, 23, 0, 0, 0, time.UTC),
+		time.Date(2000, time.February, 27, 22, 0, 0, 0, time.UTC),
+		time.Date(2000, time.February, 27, 23, 59, 0, 0, time.UTC),
+		time.Date(2000, time.February, 28, 0, 0, 0, 0, time.UTC),
+	}}
+	var stdout bytes.Buffer
+	e := &env{stdout: bufio.NewWriter(&stdout), stderr: &stdout, clock: clock}
+	if err := runWatch(e, []string{"-short", "-tz", "UTC", "-count", "2"}); err != nil {
+		t.Fatal(err)
+	}
+	if stdout.String() != "26H 31\n27H 31\n" {
+		t.Errorf("watch wrote %q", stdout.String())
+	}
+	expected := []time.Duration{maxSleep, maxSleep, time.Minute}
+	if len(clock.waits) != len(expected) {
+		t.Fatalf("watch slept for %v; expected %v", clock.waits, expected)
+	}
+	for i := range expected {
+		if clock.waits[i] != expected[i] {
+			t.Errorf("watch slept for %v; expected %v", clock.waits, expected)
+			break
+		}
+	}
+}
+
+func TestWatchUsage(t *testing.T) {
+	for _, args := range [][]string{{"watch", "extra"}, {"watch", "-count", "-1"}, {"watch", "-tz", "Mars/Olympus_Mons"}} {
+		if code, _, _ := runTest(t, "", args...); code != exitUsage {
+			t.Errorf("tqdate %q exited with %d, expected %d", args, code, exitUsage)
+		}
+	}
+}
@@ -0,0 +1,695 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+//yamlLine is a line of a YAML document. Text is the line without its indentation, comment or trailing spaces, and is empty for blank lines and comments.
+type yamlLine struct {
+	number int
+	indent int
+	text   string
+	raw    string
+}
+
+//yamlDecoder reads the documents of a YAML stream one at a time, into the same nodes as JSON so that jsonTransformer can convert them. It reads the subset of YAML found in configuration files and fixtures: block mappings and sequences, plain, single-quoted and double-quoted scalars, literal and folded block scalars, flow collections, comments and streams of documents separated by "---". Anchors, aliases, tags and complex keys are rejected rather than misread.
+type yamlDecoder struct {
+	lines []yamlLine
+	pos   int
+}
+
+//newYAMLDecoder reads the whole of r, which is split into documents as they are decoded.
+func newYAMLDecoder(r io.Reader) (*yamlDecoder, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if !utf8.Valid(data) {
+		return nil, errors.New("YAML input is not UTF-8")
+	}
+	text := strings.TrimPrefix(string(data), "\ufeff")
+	text = strings.Replace(text, "\r\n", "\n", -1)
+	text = strings.TrimSuffix(text, "\n")
+	d := &yamlDecoder{}
+	if text == "" {
+		return d, nil
+	}
+	for i, raw := range strings.Split(text, "\n") {
+		content := strings.TrimLeft(raw, " ")
+		d.lines = append(d.lines, yamlLine{number: i + 1, indent: len(raw) - len(content), text: stripYAMLComment(content), raw: raw})
+	}
+	return d, nil
+}
+
+//stripYAMLComment removes the comment and trailing spaces from the line s. A '#' starts a comment at the start of s or after a space, unless it is inside a quoted scalar.
+func stripYAMLComment(s string) string {
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote == '"' && c == '\\':
+			i++
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case (c == '"' || c == '\'') && startsYAMLScalar(s[:i]):
+			quote = c
+		case c == '#' && (i == 0 || s[i-1] == ' ' || s[i-1] == '\t'):
+			return strings.TrimRight(s[:i], " \t")
+		}
+	}
+	return strings.TrimRight(s, " \t")
+}
+
+//startsYAMLScalar reports whether a scalar may start after before, the part of a line preceding it: a quote anywhere else, as in "it's", is part of a plain scalar.
+func startsYAMLScalar(before string) bool {
+	before = strings.TrimRight(before, " \t")
+	return before == "" || strings.IndexByte("-:,[{?", before[len(before)-1]) >= 0
+}
+
+//isMarker reports whether line i is one of the markers "---" and "...", which start and end documents.
+func (d *yamlDecoder) isMarker(i int) bool {
+	l := d.lines[i]
+	if l.indent != 0 || !(strings.HasPrefix(l.text, "---") || strings.HasPrefix(l.text, "...")) {
+		return false
+	}
+	return len(l.text) == 3 || l.text[3] == ' ' || l.text[3] == '\t'
+}
+
+//next returns the next document of the stream, or io.EOF after the last. Documents with no content, such as the one before a leading "---", are skipped.
+func (d *yamlDecoder) next() (*jsonNode, error) {
+	for d.pos < len(d.lines) {
+		if l := d.lines[d.pos]; d.isMarker(d.pos) {
+			if len(l.text) > 3 {
+				return nil, fmt.Errorf("line %d: content on the same line as %s is not supported", l.number, l.text[:3])
+			}
+			d.pos++
+			continue
+		}
+		start := d.pos
+		for d.pos < len(d.lines) && !d.isMarker(d.pos) {
+			d.pos++
+		}
+		p := yamlParser{lines: d.lines[start:d.pos]}
+		//Directives such as "%YAML 1.2" come before the content.
+		for p.skipBlank() && p.cur().indent == 0 && strings.HasPrefix(p.cur().text, "%") {
+			p.pos++
+		}
+		if !p.skipBlank() {
+			continue
+		}
+		doc, err := p.block()
+		if err != nil {
+			return nil, err
+		}
+		if p.skipBlank() {
+			return nil, fmt.Errorf("line %d: unexpected content after the end of the document", p.cur().number)
+		}
+		return doc, nil
+	}
+	return nil, io.EOF
+}
+
+//yamlParser parses the lines of one document.
+type yamlParser struct {
+	lines []yamlLine
+	pos   int
+}
+
+func (p *yamlParser) cur() yamlLine {
+	return p.lines[p.pos]
+}
+
+//skipBlank moves past blank lines and comments, and reports whether any lines remain.
+func (p *yamlParser) skipBlank() bool {
+	for p.pos < len(p.lines) && p.lines[p.pos].text == "" {
+		p.pos++
+	}
+	return p.pos < len(p.lines)
+}
+
+//isSequenceEntry reports whether text starts an entry of a block sequence.
+func isSequenceEntry(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+//block parses the node starting at the current line, whose indentation is that of the node.
+func (p *yamlParser) block() (*jsonNode, error) {
+	l := p.cur()
+	if err := p.checkIndent(l.indent); err != nil {
+		return nil, err
+	}
+	if isSequenceEntry(l.text) {
+		return p.sequence(l.indent)
+	}
+	if _, _, ok, err := splitYAMLKey(l.text); err != nil {
+		return nil, fmt.Errorf("line %d: %v", l.number, err)
+	} else if ok {
+		return p.mapping(l.indent)
+	}
+	p.pos++
+	return p.value(l.text, l.number, l.indent-1)
+}
+
+//sequence parses the block sequence whose entries start at column indent.
+func (p *yamlParser) sequence(indent int) (*jsonNode, error) {
+	n := &jsonNode{kind: '['}
+	for p.skipBlank() && p.cur().indent == indent && isSequenceEntry(p.cur().text) {
+		l := p.cur()
+		rest := strings.TrimLeft(l.text[1:], " ")
+		var v *jsonNode
+		var err error
+		switch {
+		case rest == "":
+			p.pos++
+			v, err = p.child(indent, false)
+		case isSequenceEntry(rest) || isYAMLKey(rest):
+			//The entry is itself a collection, whose first line continues after the dash.
+			p.lines[p.pos].indent += len(l.text) - len(rest)
+			p.lines[p.pos].text = rest
+			v, err = p.block()
+		default:
+			p.pos++
+			v, err = p.value(rest, l.number, indent)
+		}
+		if err != nil {
+			return nil, err
+		}
+		n.values = append(n.values, v)
+		if err := p.checkIndent(indent); err != nil {
+			return nil, err
+		}
+	}
+	return n, nil
+}
+
+//mapping parses the block mapping whose keys start at column indent.
+func (p *yamlParser) mapping(indent int) (*jsonNode, error) {
+	n := &jsonNode{kind: '{'}
+	for p.skipBlank() && p.cur().indent == indent && !isSequenceEntry(p.cur().text) {
+		l := p.cur()
+		key, rest, ok, err := splitYAMLKey(l.text)
+		if err == nil && !ok {
+			err = errors.New("expected a mapping key")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", l.number, err)
+		}
+		p.pos++
+		var v *jsonNode
+		if rest == "" {
+			v, err = p.child(indent, true)
+		} else {
+			v, err = p.value(rest, l.number, indent)
+		}
+		if err != nil {
+			return nil, err
+		}
+		n.keys = append(n.keys, key)
+		n.values = append(n.values, v)
+		if err := p.checkIndent(indent); err != nil {
+			return nil, err
+		}
+	}
+	return n, nil
+}
+
+//checkIndent returns an error if the next line is indented more than a collection at column indent, without belonging to any of its entries, or is indented with tabs, which YAML does not allow.
+func (p *yamlParser) checkIndent(indent int) error {
+	switch {
+	case !p.skipBlank():
+	case p.cur().indent > indent:
+		return fmt.Errorf("line %d: unexpected indentation", p.cur().number)
+	case p.cur().text[0] == '\t':
+		return fmt.Errorf("line %d: YAML cannot be indented with tabs", p.cur().number)
+	}
+	return nil
+}
+
+//child parses the value of an entry written on the lines after it, for an entry at column indent. A mapping entry may also be followed by a sequence at its own indentation. With no such lines the value is null.
+func (p *yamlParser) child(indent int, inMapping bool) (*jsonNode, error) {
+	if p.skipBlank() {
+		l := p.cur()
+		if l.indent > indent || (inMapping && l.indent == indent && isSequenceEntry(l.text)) {
+			return p.block()
+		}
+	}
+	return &jsonNode{}, nil
+}
+
+//value parses the scalar or flow collection text, which starts on line number, along with any lines after it indented more than parent.
+func (p *yamlParser) value(text string, number, parent int) (*jsonNode, error) {
+	switch text[0] {
+	case '&', '*', '!':
+		return nil, fmt.Errorf("line %d: YAML anchors, aliases and tags are not supported", number)
+	case '|', '>':
+		s, err := p.blockScalar(text, number, parent)
+		return &jsonNode{scalar: s}, err
+	}
+	multiline := false
+	for p.skipBlank() && p.cur().indent > parent {
+		text += " " + p.cur().text
+		p.pos++
+		multiline = true
+	}
+	var n *jsonNode
+	var rest string
+	var err error
+	if text[0] == '[' || text[0] == '{' || text[0] == '"' || text[0] == '\'' {
+		n, rest, err = parseYAMLFlow(text)
+	} else if strings.Contains(text, ": ") || strings.HasSuffix(text, ":") {
+		err = errors.New("a plain scalar cannot contain \": \"; quote it")
+	} else {
+		n = &jsonNode{scalar: resolveYAMLPlain(text), text: text}
+	}
+	if err == nil && strings.TrimSpace(rest) != "" {
+		err = fmt.Errorf("unexpected %q after the value", strings.TrimSpace(rest))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("line %d: %v", number, err)
+	}
+	if multiline {
+		n.text = ""
+	}
+	return n, nil
+}
+
+//blockScalar parses the literal or folded block scalar whose header, such as "|" or ">-", is on line number, with its content on the following lines indented more than parent.
+func (p *yamlParser) blockScalar(header string, number, parent int) (string, error) {
+	folded := header[0] == '>'
+	chomp := byte(0)
+	indent := 0
+	for _, c := range []byte(header[1:]) {
+		switch {
+		case (c == '-' || c == '+') && chomp == 0:
+			chomp = c
+		case c >= '1' && c <= '9' && indent == 0:
+			indent = int(c - '0')
+			if parent > 0 {
+				indent += parent
+			}
+		default:
+			return "", fmt.Errorf("line %d: invalid block scalar header %q", number, header)
+		}
+	}
+	var lines []string
+	for ; p.pos < len(p.lines); p.pos++ {
+		raw := p.lines[p.pos].raw
+		content := strings.TrimLeft(raw, " ")
+		if content == "" {
+			lines = append(lines, "")
+			continue
+		}
+		n := len(raw) - len(content)
+		if n <= parent {
+			break
+		}
+		if indent == 0 {
+			indent = n
+		}
+		if n < indent {
+			return "", fmt.Errorf("line %d: block scalar line is indented less than the first", p.lines[p.pos].number)
+		}
+		lines = append(lines, raw[indent:])
+	}
+	//Trailing blank lines are only kept with the "+" indicator, and may belong to what follows.
+	trailing := 0
+	for trailing < len(lines) && lines[len(lines)-1-trailing] == "" {
+		trailing++
+	}
+	lines = lines[:len(lines)-trailing]
+	if len(lines) == 0 {
+		if chomp == '+' {
+			return strings.Repeat("\n", trailing), nil
+		}
+		return "", nil
+	}
+	var b strings.Builder
+	for i, l := range lines {
+		if i > 0 {
+			//Folding joins lines with a space, and drops the line break before blank lines, unless a line is more indented than the others.
+			prev := lines[i-1]
+			switch {
+			case !folded || prev == "" || prev[0] == ' ' || (l != "" && l[0] == ' '):
+				b.WriteByte('\n')
+			case l != "":
+				b.WriteByte(' ')
+			default:
+				next := i
+				for lines[next] == "" {
+					next++
+				}
+				if lines[next][0] == ' ' {
+					b.WriteByte('\n')
+				}
+			}
+		}
+		b.WriteString(l)
+	}
+	switch chomp {
+	case '-':
+	case '+':
+		b.WriteString(strings.Repeat("\n", trailing+1))
+	default:
+		b.WriteByte('\n')
+	}
+	return b.String(), nil
+}
+
+//isYAMLKey reports whether text starts with a mapping key.
+func isYAMLKey(text string) bool {
+	_, _, ok, err := splitYAMLKey(text)
+	return ok || err != nil
+}
+
+//splitYAMLKey splits the line text of a block mapping entry into its key and the text of its value, which is empty if the value is on the following lines. The result is false if text is not a mapping entry.
+func splitYAMLKey(text string) (string, string, bool, error) {
+	switch text[0] {
+	case '?':
+		if text == "?" || text[1] == ' ' {
+			return "", "", false, errors.New("complex mapping keys are not supported")
+		}
+	case '[', '{':
+		return "", "", false, nil
+	case '"', '\'':
+		key, rest, err := parseYAMLQuoted(text)
+		if err != nil {
+			return "", "", false, nil
+		}
+		rest = strings.TrimLeft(rest, " ")
+		if rest != ":" && !strings.HasPrefix(rest, ": ") {
+			return "", "", false, nil
+		}
+		return key, strings.TrimLeft(rest[1:], " "), true, nil
+	}
+	i := strings.Index(text, ": ")
+	switch {
+	case i < 0 && strings.HasSuffix(text, ":"):
+		i = len(text) - 1
+	case i < 0:
+		return "", "", false, nil
+	}
+	key := strings.TrimRight(text[:i], " ")
+	if key == "" {
+		return "", "", false, nil
+	}
+	if key[0] == '&' || key[0] == '*' || key[0] == '!' {
+		return "", "", false, errors.New("YAML anchors, aliases and tags are not supported")
+	}
+	return key, strings.TrimLeft(text[i+1:], " "), true, nil
+}
+
+//parseYAMLFlow parses the flow node at the start of s: a quoted scalar, a flow sequence or mapping, or a plain scalar, which ends at the punctuation of the collection around it. It returns the rest of s.
+func parseYAMLFlow(s string) (*jsonNode, string, error) {
+	s = strings.TrimLeft(s, " ")
+	if s == "" {
+		return nil, "", errors.New("unexpected end of a flow collection")
+	}
+	switch s[0] {
+	case '"', '\'':
+		v, rest, err := parseYAMLQuoted(s)
+		if err != nil {
+			return nil, "", err
+		}
+		return &jsonNode{scalar: v, text: s[:len(s)-len(rest)]}, rest, nil
+	case '[', '{':
+		return parseYAMLCollection(s)
+	case '&', '*', '!':
+		return nil, "", errors.New("YAML anchors, aliases and tags are not supported")
+	}
+	end := len(s)
+	for i := 0; i < len(s); i++ {
+		if strings.IndexByte(",[]{}", s[i]) >= 0 || (s[i] == ':' && (i+1 == len(s) || strings.IndexByte(" ,[]{}", s[i+1]) >= 0)) {
+			end = i
+			break
+		}
+	}
+	text := strings.TrimRight(s[:end], " ")
+	if text == "" {
+		return &jsonNode{}, s[end:], nil
+	}
+	return &jsonNode{scalar: resolveYAMLPlain(text), text: text}, s[end:], nil
+}
+
+//parseYAMLCollection parses the flow sequence or mapping at the start of s, and returns the rest of s.
+func parseYAMLCollection(s string) (*jsonNode, string, error) {
+	n := &jsonNode{kind: json.Delim(s[0])}
+	end := byte(']')
+	if n.kind == '{' {
+		end = '}'
+	}
+	s = strings.TrimLeft(s[1:], " ")
+	for len(s) == 0 || s[0] != end {
+		v, rest, err := parseYAMLFlow(s)
+		if err != nil {
+			return nil, "", err
+		}
+		rest = strings.TrimLeft(rest, " ")
+		if n.kind == '{' {
+			key, ok := v.scalar.(string)
+			if v.kind != 0 || (!ok && v.text == "") {
+				return nil, "", errors.New("flow mapping keys must be scalars")
+			} else if !ok {
+				key = v.text
+			}
+			v = &jsonNode{}
+			if strings.HasPrefix(rest, ":") {
+				if v, rest, err = parseYAMLFlow(rest[1:]); err != nil {
+					return nil, "", err
+				}
+				rest = strings.TrimLeft(rest, " ")
+			}
+			n.keys = append(n.keys, key)
+		} else if strings.HasPrefix(rest, ":") {
+			return nil, "", errors.New("mappings inside flow sequences are not supported")
+		}
+		n.values = append(n.values, v)
+		switch {
+		case strings.HasPrefix(rest, ","):
+			s = strings.TrimLeft(rest[1:], " ")
+		case len(rest) > 0 && rest[0] == end:
+			s = rest
+		default:
+			return nil, "", fmt.Errorf("expected ',' or '%c' in a flow collection", end)
+		}
+	}
+	return n, s[1:], nil
+}
+
+//parseYAMLQuoted parses the single-quoted or double-quoted scalar at the start of s, and returns its value and the rest of s.
+func parseYAMLQuoted(s string) (string, string, error) {
+	var b strings.Builder
+	if s[0] == '\'' {
+		for i := 1; i < len(s); i++ {
+			switch {
+			case s[i] != '\'':
+				b.WriteByte(s[i])
+			case i+1 < len(s) && s[i+1] == '\'':
+				b.WriteByte('\'')
+				i++
+			default:
+				return b.String(), s[i+1:], nil
+			}
+		}
+		return "", "", errors.New("unterminated single-quoted scalar")
+	}
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			return b.String(), s[i+1:], nil
+		case '\\':
+			if i+1 == len(s) {
+				break
+			}
+			i++
+			if r, ok := yamlEscapes[s[i]]; ok {
+				b.WriteRune(r)
+				continue
+			}
+			size := map[byte]int{'x': 2, 'u': 4, 'U': 8}[s[i]]
+			if size == 0 || i+size >= len(s) {
+				return "", "", fmt.Errorf("invalid escape %q in a double-quoted scalar", s[i-1:i+1])
+			}
+			r, err := strconv.ParseUint(s[i+1:i+1+size], 16, 32)
+			if err != nil {
+				return "", "", fmt.Errorf("invalid escape %q in a double-quoted scalar", s[i-1:i+1+size])
+			}
+			b.WriteRune(rune(r))
+			i += size
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return "", "", errors.New("unterminated double-quoted scalar")
+}
+
+//yamlEscapes are the single character escapes of double-quoted scalars.
+var yamlEscapes = map[byte]rune{
+	'0': 0, 'a': '\a', 'b': '\b', 't': '\t', '\t': '\t', 'n': '\n', 'v': '\v', 'f': '\f', 'r': '\r', 'e': 0x1b,
+	' ': ' ', '"': '"', '/': '/', '\\': '\\', 'N': 0x85, '_': 0xa0, 'L': 0x2028, 'P': 0x2029,
+}
+
+//yamlNumber matches the integers and floating point numbers of the YAML 1.2 core schema.
+var yamlNumber = regexp.MustCompile(`^(?:[-+]?(?:\.[0-9]+|[0-9]+(?:\.[0-9]*)?)(?:[eE][-+]?[0-9]+)?|0o[0-7]+|0x[0-9a-fA-F]+|[-+]?\.(?:inf|Inf|INF)|\.(?:nan|NaN|NAN))$`)
+
+//resolveYAMLPlain returns the value of the plain scalar s under the YAML 1.2 core schema: nil, a bool, a json.Number, or otherwise the string itself. Dates are strings, as they are in YAML 1.2.
+func resolveYAMLPlain(s string) interface{} {
+	switch s {
+	case "", "~", "null", "Null", "NULL":
+		return nil
+	case "true", "True", "TRUE":
+		return true
+	case "false", "False", "FALSE":
+		return false
+	}
+	if yamlNumber.MatchString(s) {
+		return json.Number(s)
+	}
+	return s
+}
+
+//yamlPlainSafe reports whether the string s can be written as a plain scalar, and read back as the same string by YAML 1.1 and 1.2 readers alike. Inside a flow collection the punctuation of collections must be quoted as well.
+func yamlPlainSafe(s string, flow bool) bool {
+	if _, ok := resolveYAMLPlain(s).(string); !ok {
+		return false
+	}
+	switch strings.ToLower(s) {
+	case "y", "n", "yes", "no", "on", "off":
+		return false
+	}
+	return yamlPlainKey(s, flow)
+}
+
+//yamlPlainKey reports whether the mapping key s can be written plain. Keys are read as strings whatever they look like, so a key such as "1" or "on" is written back as it was read.
+func yamlPlainKey(s string, flow bool) bool {
+	if s == "" || s != strings.TrimSpace(s) || strings.IndexByte("-?:,[]{}#&*!|>'\"%@`", s[0]) >= 0 {
+		return false
+	}
+	if strings.Contains(s, ": ") || strings.Contains(s, " #") || strings.HasSuffix(s, ":") || (flow && strings.ContainsAny(s, ",[]{}")) {
+		return false
+	}
+	for _, r := range s {
+		if r < ' ' || r == 0x7f || r == 0x85 || r == 0x2028 || r == 0x2029 || r == 0xfeff {
+			return false
+		}
+	}
+	return true
+}
+
+//yamlScalar returns the YAML text of the scalar node n, as it was written if it has not been changed.
+func yamlScalar(n *jsonNode, flow bool) string {
+	if n.text != "" && (!flow || n.text[0] == '"' || n.text[0] == '\'' || !strings.ContainsAny(n.text, ",[]{}")) {
+		return n.text
+	}
+	switch v := n.scalar.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(v)
+	case json.Number:
+		return string(v)
+	case string:
+		if yamlPlainSafe(v, flow) {
+			return v
+		}
+	}
+	//A JSON string is also a valid double-quoted YAML scalar.
+	return string(marshalScalar(n.scalar))
+}
+
+//yamlKey returns the YAML text of a mapping key.
+func yamlKey(k string, flow bool) string {
+	if yamlPlainKey(k, flow) {
+		return k
+	}
+	return string(marshalScalar(k))
+}
+
+//encodeYAML writes n to w as a YAML document. With an empty indent, n is written in flow style on one line, and otherwise in block style, with indent for each level of nesting. The indent must be made of spaces.
+func (n *jsonNode) encodeYAML(w *bufio.Writer, indent string) {
+	switch {
+	case n.kind == 0:
+		w.WriteString(yamlScalar(n, false))
+		w.WriteByte('\n')
+		return
+	case indent == "" || len(n.values) == 0:
+		n.encodeYAMLFlow(w)
+		w.WriteByte('\n')
+		return
+	}
+	n.encodeYAMLBlock(w, len(indent), 0, false)
+}
+
+//encodeYAMLFlow writes n in flow style.
+func (n *jsonNode) encodeYAMLFlow(w *bufio.Writer) {
+	switch n.kind {
+	case 0:
+		w.WriteString(yamlScalar(n, true))
+		return
+	case '[':
+		w.WriteByte('[')
+	default:
+		w.WriteByte('{')
+	}
+	for i, v := range n.values {
+		if i > 0 {
+			w.WriteString(", ")
+		}
+		if n.kind == '{' {
+			w.WriteString(yamlKey(n.keys[i], true))
+			w.WriteString(": ")
+		}
+		v.encodeYAMLFlow(w)
+	}
+	if n.kind == '[' {
+		w.WriteByte(']')
+	} else {
+		w.WriteByte('}')
+	}
+}
+
+//encodeYAMLBlock writes the non-empty collection n in block style, with each entry on a line indented to column. If started is true, the indentation of the first line has already been written, after the dash of a sequence entry.
+func (n *jsonNode) encodeYAMLBlock(w *bufio.Writer, step, column int, started bool) {
+	for i, v := range n.values {
+		if i > 0 || !started {
+			w.WriteString(strings.Repeat(" ", column))
+		}
+		if n.kind == '[' {
+			w.WriteByte('-')
+			if v.kind != 0 && len(v.values) > 0 {
+				//Collections in a sequence start on the line of their dash.
+				w.WriteByte(' ')
+				v.encodeYAMLBlock(w, step, column+2, true)
+				continue
+			}
+		} else {
+			w.WriteString(yamlKey(n.keys[i], false))
+			w.WriteByte(':')
+		}
+		if v.kind != 0 && len(v.values) > 0 {
+			w.WriteByte('\n')
+			v.encodeYAMLBlock(w, step, column+step, false)
+			continue
+		}
+		switch {
+		case v.kind != 0:
+			w.WriteString(" ")
+			v.encodeYAMLFlow(w)
+		case v.scalar != nil || v.text != "":
+			w.WriteString(" ")
+			w.WriteString(yamlScalar(v, false))
+		}
+		//A value which was left empty is still empty.
+		w.WriteByte('\n')
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestYAML(t *testing.T) {
+	var yamlTests = []struct {
+		args   []string
+		input  string
+		output string
+	}{
+		{
+			//Without paths, only RFC 3339 timestamps are converted, and other scalars are written as they were.
+			[]string{"yaml", "-short"},
+			"# fixture\nat: 2000-02-29T00:00:00Z # leap\non: '19/07/1972'\nn: 0x1F\nok: yes\nnone:\ntags:\n- \"1969-07-20T00:00:00Z\"\n- [a, b]\n",
+			"at: ALD 31\non: '19/07/1972'\nn: 0x1F\nok: yes\nnone:\ntags:\n  - MNL 0\n  - - a\n    - b\n",
+		},
+		{
+			[]string{"yaml", "-short", "-suffix", "_tq", "-path", "orders.*.when"},
+			"orders:\n  - id: 1\n    when: 1972-07-19\n    when_tq: old\n  - {id: 2, when: \"1969-07-20\"}\n",
+			"orders:\n  - id: 1\n    when: 1972-07-19\n    when_tq: 28M 3\n  - id: 2\n    when: \"1969-07-20\"\n    when_tq: MNL 0\n",
+		},
+		{
+			//Dates which need quoting in YAML are quoted.
+			[]string{"yaml", "-path", "a", "-path", "b", "-format", "%x: %K"},
+			"a: 1972-07-19T09:30:00Z\nb: \"2000-02-29T00:00:00Z\"\n",
+			"a: \"Thursday, 28 Mendel, 3 After Tranquility: 28M\"\nb: \"Aldrin Day, 31 After Tranquility: ALD\"\n",
+		},
+		{
+			[]string{"yaml", "-short", "-indent", ""},
+			"---\na: 2000-02-29T00:00:00Z\nb: {c: [\"x, y\", 2]}\n---\n2000-02-29T00:00:00Z\n...\n",
+			"{a: ALD 31, b: {c: [\"x, y\", 2]}}\n---\nALD 31\n",
+		},
+		{
+			[]string{"yaml", "-short", "-indent", "    "},
+			"a:\n  b:\n  - 2000-02-29T00:00:00Z\n",
+			"a:\n    b:\n        - ALD 31\n",
+		},
+		{
+			//Block scalars are written as double-quoted scalars.
+			[]string{"yaml"},
+			"lit: |\n  one\n   # two\n\nfold: >-\n  a\n  b\n\n  c\nkeep: |+\n  x\n\nend: 1\n",
+			"lit: \"one\\n # two\\n\"\nfold: \"a b\\nc\"\nkeep: \"x\\n\\n\"\nend: 1\n",
+		},
+	}
+	for _, tt := range yamlTests {
+		code, stdout, stderr := runTest(t, tt.input, tt.args...)
+		if code != exitOK || stdout != tt.output {
+			t.Errorf("tqdate %q = %d, %q, %q; expected %q", tt.args, code, stdout, stderr, tt.output)
+		}
+	}
+}
+
+func TestYAMLErrors(t *testing.T) {
+	var errorTests = []struct {
+		args  []string
+		input string
+		code  int
+		err   string
+	}{
+		{[]string{"yaml", "-path", "a"}, "a: 1\n", exitFailure, "document 1: at a: expected a date, found 1"},
+		{[]string{"yaml"}, "a: 1\n---\nb: &anchor 2\n", exitFailure, "document 2: line 3: YAML anchors, aliases and tags are not supported"},
+		{[]string{"yaml"}, "a: b: c\n", exitFailure, "line 1: a plain scalar cannot contain"},
+		{[]string{"yaml"}, "a:\n\tb: 1\n", exitFailure, "line 2: YAML cannot be indented with tabs"},
+		{[]string{"yaml"}, "a:\n    b: 1\n  c: 2\n", exitFailure, "line 3: unexpected indentation"},
+		{[]string{"yaml"}, "a: \"open\n", exitFailure, "unterminated double-quoted scalar"},
+		{[]string{"yaml"}, "? a\n: b\n", exitFailure, "complex mapping keys are not supported"},
+		{[]string{"yaml", "-indent", "\t"}, "", exitUsage, "YAML can only be indented with spaces"},
+	}
+	for _, tt := range errorTests {
+		code, _, stderr := runTest(t, tt.input, tt.args...)
+		if code != tt.code || !strings.Contains(stderr, tt.err) {
+			t.Errorf("tqdate %q = %d, %q; expected %d and an error containing %q", tt.args, code, stderr, tt.code, tt.err)
+		}
+	}
+}
+
+func TestYAMLPlainSafe(t *testing.T) {
+	var plainTests = []struct {
+		s    string
+		safe bool
+	}{
+		{"28M 3", true},
+		{"Thursday, 28 Mendel, 3 After Tranquility", true},
+		{"", false},
+		{"12", false},
+		{"null", false},
+		{"off", false},
+		{"a: b", false},
+		{"a #b", false},
+		{"-3", false},
+		{" x", false},
+		{"line\nbreak", false},
+	}
+	for _, tt := range plainTests {
+		if safe := yamlPlainSafe(tt.s, false); safe != tt.safe {
+			t.Errorf("yamlPlainSafe(%q) = %v; expected %v", tt.s, safe, tt.safe)
+		}
+	}
+	if yamlPlainSafe("a, b", true) {
+		t.Errorf("yamlPlainSafe(%q) is true inside a flow collection", "a, b")
+	}
+}
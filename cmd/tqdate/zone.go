@@ -0,0 +1,98 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var offsetPattern = regexp.MustCompile(`^(?:UTC)?([+-])(\d{1,2})(?::?(\d{2}))?$`)
+
+//parseZone reads a time zone given on the command line: "local" for the local time zone, "UTC", an IANA name such as "Australia/Brisbane", or an offset from UTC such as "+10", "+10:00", "-0800" or "UTC-8".
+func parseZone(s string) (*time.Location, error) {
+	switch strings.ToLower(s) {
+	case "":
+		return nil, errors.New("the time zone is empty")
+	case "local":
+		return time.Local, nil
+	case "utc", "z":
+		return time.UTC, nil
+	}
+	if m := offsetPattern.FindStringSubmatch(s); m != nil {
+		hours, _ := strconv.Atoi(m[2])
+		var minutes int
+		if m[3] != "" {
+			minutes, _ = strconv.Atoi(m[3])
+		}
+		if hours > 14 || minutes > 59 {
+			return nil, fmt.Errorf("time zone offset %q is out of range", s)
+		}
+		offset := (hours*60 + minutes) * 60
+		if m[1] == "-" {
+			offset = -offset
+		}
+		return time.FixedZone(fmt.Sprintf("%s%02d:%02d", m[1], hours, minutes), offset), nil
+	}
+	loc, err := time.LoadLocation(s)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %q", s)
+	}
+	return loc, nil
+}
+
+//zoneUsage describes the -tz flag of the commands which find the day of a time.
+const zoneUsage = "time `zone` whose midnight starts each day: an IANA name such as \"Australia/Brisbane\", an offset such as \"-08:00\", \"UTC\" or \"local\""
+
+//zoneFlag is a flag.Value holding a time zone given as for parseZone.
+type zoneFlag struct {
+	loc *time.Location
+}
+
+func (z *zoneFlag) String() string {
+	if z.loc == nil {
+		return ""
+	}
+	return z.loc.String()
+}
+
+func (z *zoneFlag) Set(s string) error {
+	loc, err := parseZone(s)
+	if err == nil {
+		z.loc = loc
+	}
+	return err
+}
+
+//zoneList is a flag.Value collecting the time zones of a repeated flag.
+type zoneList []*time.Location
+
+func (z *zoneList) String() string {
+	names := make([]string, len(*z))
+	for i, loc := range *z {
+		names[i] = loc.String()
+	}
+	return strings.Join(names, " ")
+}
+
+func (z *zoneList) Set(s string) error {
+	loc, err := parseZone(s)
+	if err == nil {
+		*z = append(*z, loc)
+	}
+	return err
+}
+
+//inZone returns t in loc, whose midnight starts the Tranquility day of t. If hasTime is false, t names a whole day rather than an instant, so the day is kept and only its zone changes. A nil loc leaves t in the zone it was read in.
+func inZone(t time.Time, hasTime bool, loc *time.Location) time.Time {
+	switch {
+	case loc == nil:
+		return t
+	case !hasTime:
+		y, m, d := t.Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, loc)
+	}
+	return t.In(loc)
+}
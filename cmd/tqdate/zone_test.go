@@ -0,0 +1,77 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseZone(t *testing.T) {
+	var zoneTests = []struct {
+		input  string
+		offset int
+	}{
+		{"UTC", 0},
+		{"utc", 0},
+		{"Z", 0},
+		{"+10", 10 * 60 * 60},
+		{"+10:00", 10 * 60 * 60},
+		{"+0530", (5*60 + 30) * 60},
+		{"-08:00", -8 * 60 * 60},
+		{"UTC-8", -8 * 60 * 60},
+		{"Australia/Brisbane", 10 * 60 * 60},
+	}
+	instant := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	for _, tt := range zoneTests {
+		loc, err := parseZone(tt.input)
+		if err != nil {
+			t.Errorf("parseZone(%q) returned %v", tt.input, err)
+			continue
+		}
+		if _, offset := instant.In(loc).Zone(); offset != tt.offset {
+			t.Errorf("parseZone(%q) has offset %d, expected %d", tt.input, offset, tt.offset)
+		}
+	}
+	if loc, err := parseZone("local"); err != nil || loc != time.Local {
+		t.Errorf("parseZone(\"local\") = %v, %v", loc, err)
+	}
+	for _, bad := range []string{"+15", "+10:60", "Mars/Olympus_Mons", ""} {
+		if _, err := parseZone(bad); err == nil {
+			t.Errorf("parseZone(%q) did not return an error", bad)
+		}
+	}
+}
+
+func TestInZone(t *testing.T) {
+	brisbane := time.FixedZone("+10:00", 10*60*60)
+	instant := time.Date(1972, time.July, 20, 1, 0, 0, 0, brisbane)
+	var inZoneTests = []struct {
+		hasTime bool
+		loc     *time.Location
+		output  string
+	}{
+		{true, nil, "1972-07-20T01:00:00+10:00"},
+		{true, time.UTC, "1972-07-19T15:00:00Z"},
+		{false, time.UTC, "1972-07-20T00:00:00Z"},
+		{false, nil, "1972-07-20T01:00:00+10:00"},
+	}
+	for _, tt := range inZoneTests {
+		if actual := inZone(instant, tt.hasTime, tt.loc).Format(time.RFC3339); actual != tt.output {
+			t.Errorf("inZone(%v, %v, %v) = %s, expected %s", instant, tt.hasTime, tt.loc, actual, tt.output)
+		}
+	}
+}
+
+func TestZoneList(t *testing.T) {
+	var z zoneList
+	for _, s := range []string{"UTC", "+10"} {
+		if err := z.Set(s); err != nil {
+			t.Errorf("Set(%q) returned %v", s, err)
+		}
+	}
+	if err := z.Set("Mars/Olympus_Mons"); err == nil || len(z) != 2 {
+		t.Errorf("Set of an unknown zone returned %v, leaving %d zones", err, len(z))
+	}
+	if s := z.String(); s != "UTC +10:00" {
+		t.Errorf("String() = %q", s)
+	}
+}
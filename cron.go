@@ -0,0 +1,219 @@
+package tqtime
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//CronSchedule is a parsed cron expression whose date fields are in the Tranquility calendar. See ParseCron for the syntax.
+type CronSchedule struct {
+	minutes   uint64
+	hours     uint64
+	days      uint64
+	months    uint64
+	weekdays  uint64
+	armstrong bool
+	aldrin    bool
+}
+
+//cronField describes the values allowed in one field of a cron expression.
+type cronField struct {
+	name     string
+	min, max int
+	parse    func(string) (int, bool)
+}
+
+var cronMinute = cronField{"minute", 0, 59, nil}
+var cronHour = cronField{"hour", 0, 23, nil}
+var cronDay = cronField{"day", 1, tqMonthLen, nil}
+var cronMonth = cronField{"month", int(Archimedes), int(Mendel), parseMonthName}
+var cronWeekday = cronField{"weekday", int(Friday), int(Thursday), parseWeekdayName}
+
+//parseMonthName parses a month given by its full name or first letter, ignoring case.
+func parseMonthName(s string) (int, bool) {
+	for m := Archimedes; m <= Mendel; m++ {
+		if strings.EqualFold(s, m.String()) || strings.EqualFold(s, MonthLetter(m)) {
+			return int(m), true
+		}
+	}
+	return 0, false
+}
+
+//parseWeekdayName parses a day of the week given by its full English name or first three letters, ignoring case.
+func parseWeekdayName(s string) (int, bool) {
+	for wd := Friday; wd <= Thursday; wd++ {
+		name := WeekdayName(wd)
+		if strings.EqualFold(s, name) || strings.EqualFold(s, name[:3]) {
+			return int(wd), true
+		}
+	}
+	return 0, false
+}
+
+//value parses a single value of f, either as a number or by name.
+func (f cronField) value(s string) (int, error) {
+	if n, err := strconv.Atoi(s); err == nil {
+		if n < f.min || n > f.max {
+			return 0, fmt.Errorf("tqtime: cron %s %d not in range [%d,%d]", f.name, n, f.min, f.max)
+		}
+		return n, nil
+	}
+	if f.parse != nil {
+		if n, ok := f.parse(s); ok {
+			return n, nil
+		}
+	}
+	return 0, fmt.Errorf("tqtime: invalid cron %s %q", f.name, s)
+}
+
+//bits parses a comma separated list of values, ranges ("a-b"), wildcards ("*") and steps ("*/n" or "a-b/n") into a bit set.
+func (f cronField) bits(s string) (uint64, error) {
+	var set uint64
+	for _, item := range strings.Split(s, ",") {
+		step := 1
+		if i := strings.Index(item, "/"); i >= 0 {
+			n, err := strconv.Atoi(item[i+1:])
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("tqtime: invalid cron %s step %q", f.name, item[i+1:])
+			}
+			item, step = item[:i], n
+		}
+		lo, hi := f.min, f.max
+		if item != "*" {
+			var err error
+			bounds := strings.SplitN(item, "-", 2)
+			if lo, err = f.value(bounds[0]); err != nil {
+				return 0, err
+			}
+			hi = lo
+			if len(bounds) == 2 {
+				if hi, err = f.value(bounds[1]); err != nil {
+					return 0, err
+				}
+			}
+			if hi < lo {
+				return 0, fmt.Errorf("tqtime: invalid cron %s range %q", f.name, item)
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			set |= 1 << uint(v)
+		}
+	}
+	return set, nil
+}
+
+//ParseCron parses a cron expression with five fields separated by spaces: minute, hour, day of the Tranquility month, Tranquility month and Tranquility day of the week.
+//
+//	minute   0-59
+//	hour     0-23
+//	day      1-28, ARM (Armstrong Day) or ALD (Aldrin Day)
+//	month    1-13, a month name such as Kepler, or its first letter such as K
+//	weekday  1-7 starting from Friday, or a day name such as Friday or Fri
+//
+//Each field may be a wildcard "*", a value, a range "a-b", a step "*/n" or "a-b/n", or a comma separated list of these. Unlike traditional cron, a day must match every field, so "0 9 * * Fri" runs at 09:00 on every Friday. Special days are not part of any month or week, so they only match when named in the day field, as in "0 0 ARM * *"; the month and weekday fields are ignored for them.
+func ParseCron(expr string) (*CronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("tqtime: cron expression %q does not have 5 fields", expr)
+	}
+	s := &CronSchedule{}
+	var err error
+	if s.minutes, err = cronMinute.bits(fields[0]); err != nil {
+		return nil, err
+	}
+	if s.hours, err = cronHour.bits(fields[1]); err != nil {
+		return nil, err
+	}
+	var ordinary []string
+	for _, item := range strings.Split(fields[2], ",") {
+		switch {
+		case strings.EqualFold(item, DayCode(ArmstrongDay)):
+			s.armstrong = true
+		case strings.EqualFold(item, DayCode(AldrinDay)):
+			s.aldrin = true
+		default:
+			ordinary = append(ordinary, item)
+		}
+	}
+	if len(ordinary) > 0 {
+		if s.days, err = cronDay.bits(strings.Join(ordinary, ",")); err != nil {
+			return nil, err
+		}
+	}
+	if s.months, err = cronMonth.bits(fields[3]); err != nil {
+		return nil, err
+	}
+	if s.weekdays, err = cronWeekday.bits(fields[4]); err != nil {
+		return nil, err
+	}
+	if !s.everMatches() {
+		return nil, ErrRuleNeverMatches
+	}
+	return s, nil
+}
+
+//has returns true if bit v of set is set.
+func has(set uint64, v int) bool {
+	return set&(1<<uint(v)) != 0
+}
+
+//matchesDate returns true if the date fields of s match d.
+func (s *CronSchedule) matchesDate(d TqDate) bool {
+	switch d.Day {
+	case ArmstrongDay:
+		return s.armstrong
+	case AldrinDay:
+		return s.aldrin
+	case MoonLandingDay:
+		return false
+	}
+	return has(s.days, d.Day) && has(s.months, int(d.Month)) && has(s.weekdays, int(d.Weekday()))
+}
+
+//everMatches returns true if s matches at least one day. Every year has the same months and weeks, so one year is enough to check.
+func (s *CronSchedule) everMatches() bool {
+	if s.armstrong || s.aldrin {
+		return true
+	}
+	for m := Archimedes; m <= Mendel; m++ {
+		for day := 1; day <= tqMonthLen; day++ {
+			if s.matchesDate(TqDate{Year: 1, Month: m, Day: day}) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+//Next returns the first time after t, in the location of t, at which s fires.
+func (s *CronSchedule) Next(t time.Time) (time.Time, error) {
+	loc := t.Location()
+	for d := FromTime(t); ; {
+		if s.matchesDate(d) {
+			midnight, err := d.Time(loc)
+			if err != nil {
+				return time.Time{}, err
+			}
+			y, m, day := midnight.Date()
+			for h := 0; h < 24; h++ {
+				if !has(s.hours, h) {
+					continue
+				}
+				for min := 0; min < 60; min++ {
+					if !has(s.minutes, min) {
+						continue
+					}
+					if fire := time.Date(y, m, day, h, min, 0, 0, loc); fire.After(t) {
+						return fire, nil
+					}
+				}
+			}
+		}
+		var err error
+		if d, err = d.AddDays(1); err != nil {
+			return time.Time{}, err
+		}
+	}
+}
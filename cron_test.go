@@ -0,0 +1,63 @@
+package tqtime
+
+import (
+	"testing"
+	"time"
+)
+
+var cronTests = []struct {
+	expr   string
+	after  time.Time
+	output time.Time
+}{
+	{"0 0 1 * *", time.Date(2024, time.July, 18, 0, 0, 0, 0, time.UTC), time.Date(2024, time.July, 21, 0, 0, 0, 0, time.UTC)},
+	{"0 9 * * Fri", time.Date(2024, time.July, 19, 10, 0, 0, 0, time.UTC), time.Date(2024, time.July, 21, 9, 0, 0, 0, time.UTC)},
+	{"0 9 * * 1", time.Date(2024, time.July, 21, 8, 59, 59, 0, time.UTC), time.Date(2024, time.July, 21, 9, 0, 0, 0, time.UTC)},
+	{"0 9 * * friday", time.Date(2024, time.July, 21, 9, 0, 0, 0, time.UTC), time.Date(2024, time.July, 28, 9, 0, 0, 0, time.UTC)},
+	{"30 12 ARM * *", time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, time.July, 20, 12, 30, 0, 0, time.UTC)},
+	{"0 0 ALD * *", time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC), time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC)},
+	{"0 0 ald,1 H *", time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, time.February, 2, 0, 0, 0, 0, time.UTC)},
+	{"*/15 9-10 28 M Thu", time.Date(2024, time.July, 19, 9, 50, 0, 0, time.UTC), time.Date(2024, time.July, 19, 10, 0, 0, 0, time.UTC)},
+	{"0 0 1 Kepler *", time.Date(2024, time.July, 18, 0, 0, 0, 0, time.UTC), time.Date(2025, time.April, 27, 0, 0, 0, 0, time.UTC)},
+	{"0 0 1-28/7 13 *", time.Date(2024, time.June, 23, 0, 0, 0, 0, time.UTC), time.Date(2024, time.June, 29, 0, 0, 0, 0, time.UTC)},
+}
+
+func TestCronNext(t *testing.T) {
+	for _, tt := range cronTests {
+		s, err := ParseCron(tt.expr)
+		if err != nil {
+			t.Errorf("ParseCron(%q) returned %v", tt.expr, err)
+			continue
+		}
+		actual, err := s.Next(tt.after)
+		if err != nil || !actual.Equal(tt.output) {
+			t.Errorf("%q after %v = %v, %v; expected %v", tt.expr, tt.after, actual, err, tt.output)
+		}
+	}
+}
+
+func TestCronNextLocation(t *testing.T) {
+	loc := time.FixedZone("UTC+10", 10*60*60)
+	s, _ := ParseCron("0 0 1 * *")
+	actual, _ := s.Next(time.Date(2024, time.July, 20, 15, 0, 0, 0, time.UTC))
+	expected := time.Date(2024, time.July, 21, 0, 0, 0, 0, time.UTC)
+	if !actual.Equal(expected) {
+		t.Errorf("Next in UTC = %v; expected %v", actual, expected)
+	}
+	actual, _ = s.Next(time.Date(2024, time.July, 20, 15, 0, 0, 0, time.UTC).In(loc))
+	expected = time.Date(2024, time.August, 18, 0, 0, 0, 0, loc)
+	if !actual.Equal(expected) || actual.Location() != loc {
+		t.Errorf("Next in UTC+10 = %v; expected %v", actual, expected)
+	}
+}
+
+func TestParseCronInvalid(t *testing.T) {
+	for _, expr := range []string{"0 0 1 *", "0 0 29 * *", "60 0 1 * *", "0 24 1 * *", "0 0 MNL * *", "0 0 1 N *", "0 0 1 * Sat-Fri", "0 0 1 * 0", "0 0 */0 * *", "0 0 14 * Fri"} {
+		if _, err := ParseCron(expr); err == nil {
+			t.Errorf("ParseCron(%q) did not return an error", expr)
+		}
+	}
+	if _, err := ParseCron("0 0 14 * Fri"); err != ErrRuleNeverMatches {
+		t.Errorf("ParseCron of a day that is never a Friday returned %v", err)
+	}
+}
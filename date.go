@@ -0,0 +1,247 @@
+package tqtime
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//TqDate is a date in the Tranquility calendar. On ordinary days Month is in range [Archimedes,Mendel] and Day is in range [1,28]. On special days Month is SpecialDay and Day is one of ArmstrongDay, AldrinDay or MoonLandingDay. Year follows the same convention as the Year function: negative Before Tranquility, 0 for Moon Landing Day and positive After Tranquility.
+type TqDate struct {
+	Year  int64
+	Month TqMonth
+	Day   int
+}
+
+//MaxYear is the latest Tranquility year supported by the int64 based functions of this package. Dates after the end of this year produce ErrOutOfRange instead of overflowing.
+const MaxYear int64 = 1000000000000000
+
+//MinYear is the earliest Tranquility year supported by the int64 based functions of this package. Dates before the start of this year produce ErrOutOfRange instead of overflowing.
+const MinYear int64 = -MaxYear
+
+//ErrOutOfRange is returned when a date falls outside the years MinYear to MaxYear.
+var ErrOutOfRange = errors.New("tqtime: date out of range")
+
+//ErrInvalidDate is returned when a TqDate does not describe a day of the Tranquility calendar, such as 29 Mendel or Aldrin Day in a common year.
+var ErrInvalidDate = errors.New("tqtime: invalid date")
+
+//gInputLimit bounds the Gregorian year and day of year accepted by FromGregorian. It is loose enough to reach every supported Tranquility year, and tight enough that the day arithmetic in gNormalize cannot overflow.
+const gInputLimit int64 = 2 * MaxYear
+
+//FromGregorian converts a Gregorian year and day of year into a Tranquility date. Like the other functions of this package, the day of year may be outside the range of the year, and is normalized in the same way as time.Date. Unlike the int based functions, years far beyond the range of time.Time are supported: ErrOutOfRange is returned if gYear is more than twice MaxYear either side of 0, if gDayOfYear is more than 366 times that, or if the resulting Tranquility year is outside the limits MinYear and MaxYear.
+func FromGregorian(gYear, gDayOfYear int64) (TqDate, error) {
+	if gYear < -gInputLimit || gYear > gInputLimit || gDayOfYear < -gInputLimit*int64(commonYearLen+1) || gDayOfYear > gInputLimit*int64(commonYearLen+1) {
+		return TqDate{}, ErrOutOfRange
+	}
+	d := tqDateOf(gNormalize(gYear, gDayOfYear))
+	if d.Year < MinYear || d.Year > MaxYear {
+		return TqDate{}, ErrOutOfRange
+	}
+	return d, nil
+}
+
+//tqStartGYear returns the Gregorian year in which Tranquility year tqy starts. Year 0 is Moon Landing Day, which starts and ends in 1969.
+func tqStartGYear(tqy int64) int64 {
+	if tqy > 0 {
+		return gMoonLandingYear + tqy - 1
+	}
+	return gMoonLandingYear + tqy
+}
+
+//tqLeapYear returns true if Tranquility year tqy contains Aldrin Day. Aldrin Day falls in the Gregorian year after the one in which the Tranquility year starts.
+func tqLeapYear(tqy int64) bool {
+	return tqy != 0 && gLeapYear(tqStartGYear(tqy)+1)
+}
+
+//Valid returns nil if d is a day of the Tranquility calendar within the supported range of years, and otherwise ErrInvalidDate or ErrOutOfRange.
+func (d TqDate) Valid() error {
+	if d.Year < MinYear || d.Year > MaxYear {
+		return ErrOutOfRange
+	}
+	switch {
+	case d.Year == 0:
+		if d.Month != SpecialDay || d.Day != MoonLandingDay {
+			return ErrInvalidDate
+		}
+	case d.Month == SpecialDay:
+		if d.Day == ArmstrongDay && d.Year == -1 {
+			return ErrInvalidDate
+		}
+		if d.Day == AldrinDay && !tqLeapYear(d.Year) {
+			return ErrInvalidDate
+		}
+		if d.Day != ArmstrongDay && d.Day != AldrinDay {
+			return ErrInvalidDate
+		}
+	case d.Month < Archimedes || d.Month > Mendel || d.Day < 1 || d.Day > tqMonthLen:
+		return ErrInvalidDate
+	}
+	return nil
+}
+
+//yearDay returns the day of the Tranquility year of d, counting Aldrin Day. It is the inverse of tqLeapAdjustedYearDay. d must be valid.
+func (d TqDate) yearDay() int {
+	leap := tqLeapYear(d.Year)
+	var tqyd int
+	switch d.Day {
+	case MoonLandingDay:
+		return 1
+	case AldrinDay:
+		return tqydAldrin
+	case ArmstrongDay:
+		tqyd = commonYearLen
+	default:
+		tqyd = (int(d.Month)-1)*tqMonthLen + d.Day
+	}
+	if leap && tqyd >= tqydAldrin {
+		tqyd++
+	}
+	return tqyd
+}
+
+//dayNumber returns the number of days from 1 January of Gregorian year 0 to d. d must be valid.
+func (d TqDate) dayNumber() int64 {
+	gy := tqStartGYear(d.Year)
+	if d.Year == 0 {
+		return gDaysBeforeYear(gy) + int64(gCommonYearArmstrongDay) - 1
+	}
+	return gDaysBeforeYear(gy) + int64(gArmstrongDay(gy)) + int64(d.yearDay()) - 1
+}
+
+//tqFromDayNumber is the inverse of dayNumber.
+func tqFromDayNumber(n int64) TqDate {
+	return tqDateOf(gFromDayNumber(n))
+}
+
+//Compare returns -1 if d is before o, 0 if they are the same day and +1 if d is after o. Both dates must be valid.
+func (d TqDate) Compare(o TqDate) int {
+	switch {
+	case d.Year < o.Year:
+		return -1
+	case d.Year > o.Year:
+		return 1
+	}
+	dyd, oyd := d.yearDay(), o.yearDay()
+	switch {
+	case dyd < oyd:
+		return -1
+	case dyd > oyd:
+		return 1
+	}
+	return 0
+}
+
+//AddDays returns the date n days after d, or before d if n is negative. Special days are counted like any other day. An error is returned if d is not valid or the result is outside the limits MinYear and MaxYear.
+func (d TqDate) AddDays(n int64) (TqDate, error) {
+	if err := d.Valid(); err != nil {
+		return TqDate{}, err
+	}
+	const dayLimit int64 = 4 * MaxYear * int64(commonYearLen+1)
+	if n < -dayLimit || n > dayLimit {
+		return TqDate{}, ErrOutOfRange
+	}
+	r := tqFromDayNumber(d.dayNumber() + n)
+	if r.Year < MinYear || r.Year > MaxYear {
+		return TqDate{}, ErrOutOfRange
+	}
+	return r, nil
+}
+
+//Sub returns the number of days from o to d, which is negative if d is before o. Special days are counted like any other day. An error is returned if either date is not valid.
+func (d TqDate) Sub(o TqDate) (int64, error) {
+	if err := d.Valid(); err != nil {
+		return 0, err
+	}
+	if err := o.Valid(); err != nil {
+		return 0, err
+	}
+	return d.dayNumber() - o.dayNumber(), nil
+}
+
+//Gregorian returns the Gregorian year and day of year of d. An error is returned if d is not valid.
+func (d TqDate) Gregorian() (gYear int64, gDayOfYear int, err error) {
+	if err = d.Valid(); err != nil {
+		return 0, 0, err
+	}
+	gYear, gDayOfYear = gFromDayNumber(d.dayNumber())
+	return gYear, gDayOfYear, nil
+}
+
+//Weekday returns the Tranquility day of the week of d. If d does not fall on a week, the value SpecialWeekday is returned.
+func (d TqDate) Weekday() TqWeekday {
+	if d.Day < 0 {
+		return SpecialWeekday
+	}
+	return TqWeekday(clockModulo(d.Day, 7))
+}
+
+//ShortDate returns the string representation of d in the compact format described by the ShortDate function.
+func (d TqDate) ShortDate() string {
+	var buf [32]byte
+	return string(AppendShortDate(buf[:0], d))
+}
+
+//LongDate returns the string representation of d in the descriptive format described by the LongDate function.
+func (d TqDate) LongDate() string {
+	var buf [64]byte
+	return string(AppendLongDate(buf[:0], d))
+}
+
+//gTimeLimit bounds the Gregorian years converted to time.Time by this package, well within the years that time.Time can represent.
+const gTimeLimit int64 = 100000000000
+
+//Time returns midnight at the start of d in loc. An error is returned if d is not valid, or is too far from the present to be represented as a time.Time.
+func (d TqDate) Time(loc *time.Location) (time.Time, error) {
+	gy, gyd, err := d.Gregorian()
+	if err != nil {
+		return time.Time{}, err
+	}
+	if gy < -gTimeLimit || gy > gTimeLimit {
+		return time.Time{}, ErrOutOfRange
+	}
+	return time.Date(int(gy), time.January, gyd, 0, 0, 0, 0, loc), nil
+}
+
+//ParseShortDate parses the compact format produced by ShortDate, such as "28M 3", "03C -12" or "ARM 28". Leading and trailing spaces are ignored.
+func ParseShortDate(s string) (TqDate, error) {
+	fields := strings.Fields(s)
+	if len(fields) != 2 {
+		return TqDate{}, fmt.Errorf("tqtime: cannot parse %q as a short date", s)
+	}
+	d, err := parseDayCode(fields[0])
+	if err != nil {
+		return TqDate{}, fmt.Errorf("tqtime: cannot parse %q as a short date", s)
+	}
+	d.Year, err = strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return TqDate{}, fmt.Errorf("tqtime: cannot parse %q as a short date", s)
+	}
+	if err = d.Valid(); err != nil {
+		return TqDate{}, err
+	}
+	return d, nil
+}
+
+//parseDayCode parses the 3 character code at the start of a short date, such as "28M" or "ARM", into a TqDate without a year.
+func parseDayCode(code string) (TqDate, error) {
+	if len(code) != 3 {
+		return TqDate{}, ErrInvalidDate
+	}
+	for _, special := range []int{ArmstrongDay, AldrinDay, MoonLandingDay} {
+		if code == DayCode(special) {
+			return TqDate{Month: SpecialDay, Day: special}, nil
+		}
+	}
+	day, err := strconv.Atoi(code[:2])
+	if err != nil || code[0] < '0' || code[0] > '9' {
+		return TqDate{}, ErrInvalidDate
+	}
+	for m := Archimedes; m <= Mendel; m++ {
+		if code[2:] == MonthLetter(m) {
+			return TqDate{Month: m, Day: day}, nil
+		}
+	}
+	return TqDate{}, ErrInvalidDate
+}
@@ -0,0 +1,174 @@
+package tqtime
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGregorianRoundTrip(t *testing.T) {
+	for gYear := int64(1890); gYear <= 2110; gYear++ {
+		for gDay := 1; gDay <= gYearLen(gYear); gDay++ {
+			d, err := FromGregorian(gYear, int64(gDay))
+			if err != nil {
+				t.Fatalf("FromGregorian(%d,%d) returned %v", gYear, gDay, err)
+			}
+			if err = d.Valid(); err != nil {
+				t.Errorf("FromGregorian(%d,%d) = %+v, which is not valid: %v", gYear, gDay, d, err)
+			}
+			y, yd, err := d.Gregorian()
+			if err != nil || y != gYear || yd != gDay {
+				t.Errorf("%+v.Gregorian() = (%d,%d,%v); expected (%d,%d)", d, y, yd, err, gYear, gDay)
+			}
+		}
+	}
+}
+
+func TestFromGregorianMatchesShortDate(t *testing.T) {
+	for _, tt := range NormalizeTests {
+		d, err := FromGregorian(int64(tt.gYear), int64(tt.gDay))
+		if err != nil || d.ShortDate() != tt.output {
+			t.Errorf("FromGregorian(%d,%d) = %v, %v; expected %s", tt.gYear, tt.gDay, d.ShortDate(), err, tt.output)
+		}
+	}
+}
+
+var deepTimeTests = []TqDate{
+	{1000000000000, Archimedes, 1},
+	{1000000000000, SpecialDay, ArmstrongDay},
+	{-1000000000000, Mendel, 28},
+	{-1000000000000, SpecialDay, ArmstrongDay},
+	{31, SpecialDay, AldrinDay},
+	{30031, SpecialDay, AldrinDay},
+	{MaxYear, SpecialDay, ArmstrongDay},
+	{MinYear, Archimedes, 1},
+	{0, SpecialDay, MoonLandingDay},
+}
+
+func TestDeepTimeRoundTrip(t *testing.T) {
+	for _, expected := range deepTimeTests {
+		gy, gyd, err := expected.Gregorian()
+		if err != nil {
+			t.Errorf("%+v.Gregorian() returned %v", expected, err)
+			continue
+		}
+		actual, err := FromGregorian(gy, int64(gyd))
+		if err != nil || actual != expected {
+			t.Errorf("FromGregorian(%d,%d) = %+v, %v; expected %+v", gy, gyd, actual, err, expected)
+		}
+	}
+}
+
+func TestFromGregorianLimits(t *testing.T) {
+	last, _, _ := TqDate{MaxYear, SpecialDay, ArmstrongDay}.Gregorian()
+	first, _, _ := TqDate{MinYear, Archimedes, 1}.Gregorian()
+	var limitTests = []struct {
+		gYear int64
+		gDay  int64
+		err   error
+	}{
+		{last, int64(gArmstrongDay(last)), nil},
+		{last, int64(gArmstrongDay(last)) + 1, ErrOutOfRange},
+		{first, int64(gArmstrongDay(first)) + 1, nil},
+		{first, int64(gArmstrongDay(first)), ErrOutOfRange},
+		{math.MaxInt64, 1, ErrOutOfRange},
+		{math.MinInt64, 1, ErrOutOfRange},
+		{1969, math.MaxInt64, ErrOutOfRange},
+		{1969, math.MinInt64, ErrOutOfRange},
+	}
+	for _, tt := range limitTests {
+		if _, err := FromGregorian(tt.gYear, tt.gDay); err != tt.err {
+			t.Errorf("FromGregorian(%d,%d) returned %v; expected %v", tt.gYear, tt.gDay, err, tt.err)
+		}
+	}
+}
+
+var invalidDateTests = []struct {
+	date TqDate
+	err  error
+}{
+	{TqDate{3, Mendel, 29}, ErrInvalidDate},
+	{TqDate{3, Mendel, 0}, ErrInvalidDate},
+	{TqDate{3, TqMonth(14), 1}, ErrInvalidDate},
+	{TqDate{2, SpecialDay, AldrinDay}, ErrInvalidDate},
+	{TqDate{-1, SpecialDay, ArmstrongDay}, ErrInvalidDate},
+	{TqDate{0, Archimedes, 1}, ErrInvalidDate},
+	{TqDate{1, SpecialDay, MoonLandingDay}, ErrInvalidDate},
+	{TqDate{MaxYear + 1, Archimedes, 1}, ErrOutOfRange},
+	{TqDate{MinYear - 1, Archimedes, 1}, ErrOutOfRange},
+}
+
+func TestInvalidDates(t *testing.T) {
+	for _, tt := range invalidDateTests {
+		if _, _, err := tt.date.Gregorian(); err != tt.err {
+			t.Errorf("%+v.Gregorian() returned %v; expected %v", tt.date, err, tt.err)
+		}
+	}
+}
+
+func TestParseShortDate(t *testing.T) {
+	for _, tt := range shortTests {
+		d, err := ParseShortDate(tt.output)
+		if err != nil || d.ShortDate() != tt.output {
+			t.Errorf("ParseShortDate(%q) = %+v, %v", tt.output, d, err)
+		}
+	}
+	for _, s := range []string{"", "28M", "29M 3", "ALD 2", "ARM -1", "MNL 1", "28Z 3", "+1A 3", "28M 3 3", "28M x"} {
+		if d, err := ParseShortDate(s); err == nil {
+			t.Errorf("ParseShortDate(%q) = %+v; expected an error", s, d)
+		}
+	}
+}
+
+func TestAddDays(t *testing.T) {
+	var addTests = []struct {
+		from   string
+		n      int64
+		output string
+	}{
+		{"27H 31", 1, "ALD 31"},
+		{"27H 31", 2, "28H 31"},
+		{"28M -1", 1, "MNL 0"},
+		{"28M -1", 2, "01A 1"},
+		{"01A 1", -2, "28M -1"},
+		{"28M 3", 1, "ARM 3"},
+		{"01A 55", 365, "ARM 55"},
+	}
+	for _, tt := range addTests {
+		d, _ := ParseShortDate(tt.from)
+		actual, err := d.AddDays(tt.n)
+		if err != nil || actual.ShortDate() != tt.output {
+			t.Errorf("%s plus %d days = %s, %v; expected %s", tt.from, tt.n, actual.ShortDate(), err, tt.output)
+		}
+	}
+	if _, err := (TqDate{MaxYear, SpecialDay, ArmstrongDay}).AddDays(1); err != ErrOutOfRange {
+		t.Errorf("AddDays after MaxYear returned %v", err)
+	}
+}
+
+func TestSub(t *testing.T) {
+	var subTests = []struct {
+		d      string
+		o      string
+		output int64
+	}{
+		{"ALD 31", "27H 31", 1},
+		{"01A 1", "28M -1", 2},
+		{"28M -1", "01A 1", -2},
+		{"ARM 55", "01A 55", 365},
+		{"01A 56", "01A 55", 366},
+		{"01A 33", "01A 32", 365},
+		{"01A 32", "01A 31", 366},
+		{"MNL 0", "MNL 0", 0},
+	}
+	for _, tt := range subTests {
+		d, _ := ParseShortDate(tt.d)
+		o, _ := ParseShortDate(tt.o)
+		actual, err := d.Sub(o)
+		if err != nil || actual != tt.output {
+			t.Errorf("%s minus %s = %d, %v; expected %d", tt.d, tt.o, actual, err, tt.output)
+		}
+	}
+	if _, err := (TqDate{1, Archimedes, 29}).Sub(TqDate{1, Archimedes, 1}); err != ErrInvalidDate {
+		t.Errorf("Sub of an invalid date returned %v", err)
+	}
+}
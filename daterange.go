@@ -0,0 +1,127 @@
+package tqtime
+
+import (
+	"fmt"
+	"strings"
+)
+
+//DateRange is a range of consecutive Tranquility dates, from First to Last inclusive. Special days between First and Last are part of the range like any other day, so a range may cross Aldrin Day, Armstrong Day and Moon Landing Day.
+type DateRange struct {
+	First TqDate
+	Last  TqDate
+}
+
+//rangeSeparator separates the two ends of a DateRange in its text form.
+const rangeSeparator string = "–"
+
+//NewDateRange returns the range from first to last inclusive. An error is returned if either date is not valid, or if last is before first.
+func NewDateRange(first, last TqDate) (DateRange, error) {
+	if err := first.Valid(); err != nil {
+		return DateRange{}, err
+	}
+	if err := last.Valid(); err != nil {
+		return DateRange{}, err
+	}
+	if last.Compare(first) < 0 {
+		return DateRange{}, fmt.Errorf("tqtime: range ends on %s, before it starts on %s", last.ShortDate(), first.ShortDate())
+	}
+	return DateRange{First: first, Last: last}, nil
+}
+
+//ParseDateRange parses the text form of a DateRange produced by String, such as "01A–28C 55" or "28M 55–01A 56". An ASCII hyphen is also accepted in place of the en dash.
+func ParseDateRange(s string) (DateRange, error) {
+	s = strings.TrimSpace(s)
+	for i := 0; i < len(s); i++ {
+		var sepLen int
+		switch {
+		case strings.HasPrefix(s[i:], rangeSeparator):
+			sepLen = len(rangeSeparator)
+		case s[i] == '-':
+			sepLen = 1
+		default:
+			continue
+		}
+		last, err := ParseShortDate(s[i+sepLen:])
+		if err != nil {
+			continue
+		}
+		left := strings.TrimSpace(s[:i])
+		first, err := ParseShortDate(left)
+		if err != nil {
+			first, err = parseDayCode(left)
+			first.Year = last.Year
+			if err == nil {
+				err = first.Valid()
+			}
+		}
+		if err != nil {
+			continue
+		}
+		return NewDateRange(first, last)
+	}
+	return DateRange{}, fmt.Errorf("tqtime: cannot parse %q as a date range", s)
+}
+
+//String returns the text form of r. When both ends are in the same year, the year is only written once, as in "01A–28C 55". Otherwise both ends are written in full, as in "28M 55–01A 56".
+func (r DateRange) String() string {
+	last := r.Last.ShortDate()
+	if r.First.Year == r.Last.Year {
+		return r.First.ShortDate()[:3] + rangeSeparator + last
+	}
+	return r.First.ShortDate() + rangeSeparator + last
+}
+
+//Len returns the number of days in r, including both ends.
+func (r DateRange) Len() int64 {
+	return r.Last.dayNumber() - r.First.dayNumber() + 1
+}
+
+//Contains returns true if d is within r.
+func (r DateRange) Contains(d TqDate) bool {
+	return d.Compare(r.First) >= 0 && d.Compare(r.Last) <= 0
+}
+
+//Overlaps returns true if r and o have at least one day in common.
+func (r DateRange) Overlaps(o DateRange) bool {
+	return r.First.Compare(o.Last) <= 0 && o.First.Compare(r.Last) <= 0
+}
+
+//Intersect returns the days common to r and o. The result is false if they do not overlap.
+func (r DateRange) Intersect(o DateRange) (DateRange, bool) {
+	if !r.Overlaps(o) {
+		return DateRange{}, false
+	}
+	result := r
+	if o.First.Compare(result.First) > 0 {
+		result.First = o.First
+	}
+	if o.Last.Compare(result.Last) < 0 {
+		result.Last = o.Last
+	}
+	return result, true
+}
+
+//Union returns the smallest range containing both r and o. The result is false if r and o neither overlap nor are adjacent, since the days between them would not be part of either range.
+func (r DateRange) Union(o DateRange) (DateRange, bool) {
+	if r.Last.dayNumber()+1 < o.First.dayNumber() || o.Last.dayNumber()+1 < r.First.dayNumber() {
+		return DateRange{}, false
+	}
+	result := r
+	if o.First.Compare(result.First) < 0 {
+		result.First = o.First
+	}
+	if o.Last.Compare(result.Last) > 0 {
+		result.Last = o.Last
+	}
+	return result, true
+}
+
+//Each calls fn with every date of r in order, stopping early if fn returns false.
+func (r DateRange) Each(fn func(TqDate) bool) {
+	last := r.Last.dayNumber()
+	for n := r.First.dayNumber(); n <= last; n++ {
+		if !fn(tqFromDayNumber(n)) {
+			return
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package tqtime
+
+import "testing"
+
+func mustParseRange(t *testing.T, s string) DateRange {
+	r, err := ParseDateRange(s)
+	if err != nil {
+		t.Fatalf("ParseDateRange(%q) returned %v", s, err)
+	}
+	return r
+}
+
+var rangeParseTests = []struct {
+	input  string
+	output string
+	length int64
+}{
+	{"01A–28C 55", "01A–28C 55", 84},
+	{"01A-28C 55", "01A–28C 55", 84},
+	{"28M 55–01A 56", "28M 55–01A 56", 3},
+	{" 27H–28H 31 ", "27H–28H 31", 3},
+	{"27H–28H 30", "27H–28H 30", 2},
+	{"28M -1–01A 1", "28M -1–01A 1", 3},
+	{"28M -3-01A -2", "28M -3–01A -2", 3},
+	{"ARM 55–ARM 56", "ARM 55–ARM 56", 366},
+	{"ALD–ALD 31", "ALD–ALD 31", 1},
+	{"01A–01A 55", "01A–01A 55", 1},
+}
+
+func TestParseDateRange(t *testing.T) {
+	for _, tt := range rangeParseTests {
+		r := mustParseRange(t, tt.input)
+		if r.String() != tt.output {
+			t.Errorf("ParseDateRange(%q).String() = %q; expected %q", tt.input, r.String(), tt.output)
+		}
+		if r.Len() != tt.length {
+			t.Errorf("ParseDateRange(%q).Len() = %d; expected %d", tt.input, r.Len(), tt.length)
+		}
+		var n int64
+		r.Each(func(d TqDate) bool {
+			n++
+			return r.Contains(d)
+		})
+		if n != tt.length {
+			t.Errorf("Each on %q visited %d days; expected %d", tt.input, n, tt.length)
+		}
+	}
+}
+
+func TestParseDateRangeInvalid(t *testing.T) {
+	for _, s := range []string{"", "01A", "28C–01A 55", "ALD–ALD 30", "01A–29C 55", "01A–ARM -1", "01A 55 – 01A 56 57", "XYZ–01A 55"} {
+		if r, err := ParseDateRange(s); err == nil {
+			t.Errorf("ParseDateRange(%q) = %v; expected an error", s, r)
+		}
+	}
+}
+
+var rangeSetTests = []struct {
+	a, b      string
+	overlaps  bool
+	intersect string
+	union     string
+}{
+	{"01A–28C 55", "01C–28D 55", true, "01C–28C 55", "01A–28D 55"},
+	{"01A–28C 55", "01D–28D 55", false, "", "01A–28D 55"},
+	{"01A–28C 55", "02D–28D 55", false, "", ""},
+	{"01M 55–28A 56", "ARM–ARM 55", true, "ARM–ARM 55", "01M 55–28A 56"},
+	{"20H–28H 31", "ALD–01I 31", true, "ALD–28H 31", "20H–01I 31"},
+	{"01M -1–28A 1", "MNL–MNL 0", true, "MNL–MNL 0", "01M -1–28A 1"},
+}
+
+func TestDateRangeSets(t *testing.T) {
+	for _, tt := range rangeSetTests {
+		a, b := mustParseRange(t, tt.a), mustParseRange(t, tt.b)
+		if a.Overlaps(b) != tt.overlaps || b.Overlaps(a) != tt.overlaps {
+			t.Errorf("%s overlaps %s: expected %v", a, b, tt.overlaps)
+		}
+		i, ok := a.Intersect(b)
+		if ok != (tt.intersect != "") || (ok && i.String() != tt.intersect) {
+			t.Errorf("%s intersect %s = %s, %v; expected %q", a, b, i, ok, tt.intersect)
+		}
+		u, ok := b.Union(a)
+		if ok != (tt.union != "") || (ok && u.String() != tt.union) {
+			t.Errorf("%s union %s = %s, %v; expected %q", a, b, u, ok, tt.union)
+		}
+	}
+}
+
+func TestDateRangeCrossesSpecialDays(t *testing.T) {
+	r := mustParseRange(t, "28M -2–01A 1")
+	for _, s := range []string{"ARM -2", "01A -1", "28M -1", "MNL 0", "01A 1"} {
+		d, err := ParseShortDate(s)
+		if err != nil {
+			t.Fatalf("ParseShortDate(%q) returned %v", s, err)
+		}
+		if !r.Contains(d) {
+			t.Errorf("%s does not contain %s", r, s)
+		}
+	}
+	if r.Len() != 2+364+1+1 {
+		t.Errorf("%s has %d days", r, r.Len())
+	}
+}
+
+func TestNewDateRangeBackwards(t *testing.T) {
+	if _, err := NewDateRange(TqDate{55, Copernicus, 1}, TqDate{55, Archimedes, 1}); err == nil {
+		t.Error("NewDateRange accepted a range ending before it starts.")
+	}
+}
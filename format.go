@@ -0,0 +1,128 @@
+package tqtime
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+//String returns the English name of the given day of the week, like WeekdayName. SpecialWeekday is "SpecialWeekday", and other values which are not days of the week are written like "TqWeekday(8)".
+func (wd TqWeekday) String() string {
+	switch {
+	case wd == SpecialWeekday:
+		return "SpecialWeekday"
+	case wd < Friday || wd > Thursday:
+		return "TqWeekday(" + strconv.Itoa(int(wd)) + ")"
+	}
+	return WeekdayName(wd)
+}
+
+var weekdayConstants = [...]string{"SpecialWeekday", "Friday", "Saturday", "Sunday", "Monday", "Tuesday", "Wednesday", "Thursday"}
+
+//GoString returns the Go syntax of wd, such as "tqtime.Friday", for %#v.
+func (wd TqWeekday) GoString() string {
+	if wd < SpecialWeekday || wd > Thursday {
+		return "tqtime.TqWeekday(" + strconv.Itoa(int(wd)) + ")"
+	}
+	return "tqtime." + weekdayConstants[wd]
+}
+
+//GoString returns the Go syntax of tqm, such as "tqtime.Mendel", for %#v.
+func (tqm TqMonth) GoString() string {
+	switch {
+	case tqm == SpecialDay:
+		return "tqtime.SpecialDay"
+	case tqm < SpecialDay || tqm > Mendel:
+		return "tqtime.TqMonth(" + strconv.Itoa(int(tqm)) + ")"
+	}
+	return "tqtime." + monthName(tqm)
+}
+
+//String returns y with its era, such as "3 After Tranquility" or "3 Before Tranquility". Year 0 is "Moon Landing Day".
+func (y TqYear) String() string {
+	if y == 0 {
+		return DayName(MoonLandingDay)
+	}
+	var buf [48]byte
+	return string(LongDateOptions{}.appendYear(buf[:0], int64(y)))
+}
+
+//GoString returns the Go syntax of y, such as "tqtime.TqYear(3)", for %#v.
+func (y TqYear) GoString() string {
+	return "tqtime.TqYear(" + strconv.FormatInt(int64(y), 10) + ")"
+}
+
+//String returns d in the compact format of ShortDate, which is also what %v produces.
+func (d TqDate) String() string {
+	return d.ShortDate()
+}
+
+//GoString returns the Go syntax of d, such as "tqtime.TqDate{Year: 3, Month: tqtime.Mendel, Day: 28}", for %#v. Special days are written with their constants.
+func (d TqDate) GoString() string {
+	day := strconv.Itoa(d.Day)
+	switch d.Day {
+	case ArmstrongDay:
+		day = "tqtime.ArmstrongDay"
+	case AldrinDay:
+		day = "tqtime.AldrinDay"
+	case MoonLandingDay:
+		day = "tqtime.MoonLandingDay"
+	}
+	return "tqtime.TqDate{Year: " + strconv.FormatInt(d.Year, 10) + ", Month: " + d.Month.GoString() + ", Day: " + day + "}"
+}
+
+//gregorianISO returns the Gregorian equivalent of d in the form YYYY-MM-DD, or "invalid" if d is not valid.
+func (d TqDate) gregorianISO() string {
+	gy, gyd, err := d.Gregorian()
+	if err != nil {
+		return "invalid"
+	}
+	m, day := gMonthDay(gy, gyd)
+	return fmt.Sprintf("%04d-%02d-%02d", gy, int(m), day)
+}
+
+//Format implements fmt.Formatter, so that dates print naturally with fmt, log and testing. The verbs are:
+//
+//	%s   the descriptive format of LongDate, such as "Thursday, 28 Mendel, 3 After Tranquility"
+//	%v   the compact format of ShortDate, such as "28M 3"
+//	%q   the descriptive format, double-quoted
+//	%+v  the fields of d and the Gregorian equivalent, such as "{Year:3 Month:Mendel Day:28 Gregorian:1972-07-19}"
+//	%#v  the Go syntax returned by GoString
+//
+//Width and precision apply to the whole text as they do for strings, and the '-' flag pads on the right.
+func (d TqDate) Format(f fmt.State, verb rune) {
+	var s string
+	switch {
+	case verb == 'v' && f.Flag('#'):
+		s = d.GoString()
+	case verb == 'v' && f.Flag('+'):
+		day := strconv.Itoa(d.Day)
+		if d.Day < 0 {
+			day = DayName(d.Day)
+		}
+		s = fmt.Sprintf("{Year:%d Month:%s Day:%s Gregorian:%s}", d.Year, d.Month, day, d.gregorianISO())
+	case verb == 'v':
+		s = d.ShortDate()
+	case verb == 's':
+		s = d.LongDate()
+	case verb == 'q':
+		s = strconv.Quote(d.LongDate())
+	default:
+		fmt.Fprintf(f, "%%!%c(tqtime.TqDate=%s)", verb, d.ShortDate())
+		return
+	}
+	if p, ok := f.Precision(); ok && p < len([]rune(s)) {
+		s = string([]rune(s)[:p])
+	}
+	pad := ""
+	if w, ok := f.Width(); ok {
+		for n := len([]rune(s)); n < w; n++ {
+			pad += " "
+		}
+	}
+	if f.Flag('-') {
+		io.WriteString(f, s+pad)
+	} else {
+		io.WriteString(f, pad+s)
+	}
+}
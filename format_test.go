@@ -0,0 +1,74 @@
+package tqtime
+
+import (
+	"fmt"
+	"testing"
+)
+
+var formatTests = []struct {
+	format string
+	value  interface{}
+	output string
+}{
+	{"%v", TqDate{3, Mendel, 28}, "28M 3"},
+	{"%s", TqDate{3, Mendel, 28}, "Thursday, 28 Mendel, 3 After Tranquility"},
+	{"%q", TqDate{-2, SpecialDay, AldrinDay}, `"Aldrin Day, 2 Before Tranquility"`},
+	{"%+v", TqDate{3, Mendel, 28}, "{Year:3 Month:Mendel Day:28 Gregorian:1972-07-19}"},
+	{"%+v", TqDate{31, SpecialDay, AldrinDay}, "{Year:31 Month:SpecialDay Day:Aldrin Day Gregorian:2000-02-29}"},
+	{"%+v", TqDate{3, Mendel, 29}, "{Year:3 Month:Mendel Day:29 Gregorian:invalid}"},
+	{"%+v", TqDate{}, "{Year:0 Month:SpecialDay Day:0 Gregorian:invalid}"},
+	{"%+v", TqDate{3, SpecialDay, 5}, "{Year:3 Month:SpecialDay Day:5 Gregorian:invalid}"},
+	{"%+v", TqDate{3, Mendel, -9}, "{Year:3 Month:Mendel Day:-9 Gregorian:invalid}"},
+	{"%#v", TqDate{3, Mendel, 28}, "tqtime.TqDate{Year: 3, Month: tqtime.Mendel, Day: 28}"},
+	{"%#v", TqDate{0, SpecialDay, MoonLandingDay}, "tqtime.TqDate{Year: 0, Month: tqtime.SpecialDay, Day: tqtime.MoonLandingDay}"},
+	{"[%8v]", TqDate{3, Mendel, 28}, "[   28M 3]"},
+	{"[%-8v]", TqDate{3, Mendel, 28}, "[28M 3   ]"},
+	{"[%.8s]", TqDate{3, Mendel, 28}, "[Thursday]"},
+	{"%d", TqDate{3, Mendel, 28}, "%!d(tqtime.TqDate=28M 3)"},
+	{"%v", []TqDate{{3, Mendel, 28}, {3, SpecialDay, ArmstrongDay}}, "[28M 3 ARM 3]"},
+	{"%v %d %#v", []interface{}{Mendel, Mendel, Mendel}, "Mendel 13 tqtime.Mendel"},
+	{"%#v", TqMonth(99), "tqtime.TqMonth(99)"},
+	{"%v %d %#v", []interface{}{Friday, Friday, Friday}, "Friday 1 tqtime.Friday"},
+	{"%#v", SpecialWeekday, "tqtime.SpecialWeekday"},
+	{"%#v", TqWeekday(-2), "tqtime.TqWeekday(-2)"},
+	{"%v|%v|%v", []interface{}{TqYear(3), TqYear(-3), TqYear(0)}, "3 After Tranquility|3 Before Tranquility|Moon Landing Day"},
+	{"%#v", TqYear(-3), "tqtime.TqYear(-3)"},
+}
+
+func TestFormat(t *testing.T) {
+	for _, tt := range formatTests {
+		var actual string
+		switch v := tt.value.(type) {
+		case []interface{}:
+			actual = fmt.Sprintf(tt.format, v...)
+		default:
+			actual = fmt.Sprintf(tt.format, v)
+		}
+		if actual != tt.output {
+			t.Errorf("Sprintf(%q, %#v) = %q; expected %q", tt.format, tt.value, actual, tt.output)
+		}
+	}
+}
+
+func TestWeekdayString(t *testing.T) {
+	for wd := Friday; wd <= Thursday; wd++ {
+		if wd.String() != WeekdayName(wd) {
+			t.Errorf("TqWeekday(%d).String() = %q; expected %q", wd, wd.String(), WeekdayName(wd))
+		}
+	}
+	var invalidTests = []struct {
+		value  fmt.Stringer
+		output string
+	}{
+		{SpecialWeekday, "SpecialWeekday"},
+		{TqWeekday(8), "TqWeekday(8)"},
+		{TqWeekday(-1), "TqWeekday(-1)"},
+		{SpecialDay, "SpecialDay"},
+		{TqMonth(14), "TqMonth(14)"},
+	}
+	for _, tt := range invalidTests {
+		if v, s := fmt.Sprintf("%v", tt.value), fmt.Sprintf("%s", tt.value); v != tt.output || s != tt.output {
+			t.Errorf("%%v and %%s of %#v = %q, %q; expected %q", tt.value, v, s, tt.output)
+		}
+	}
+}
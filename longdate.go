@@ -0,0 +1,252 @@
+package tqtime
+
+import (
+	"strconv"
+	"time"
+)
+
+//EraStyle selects how LongDateOptions writes the year.
+type EraStyle int
+
+//The era styles supported by LongDateOptions.
+const (
+	//EraLong writes "3 After Tranquility" or "3 Before Tranquility".
+	EraLong EraStyle = iota
+	//EraShort writes "3 AT" or "3 BT".
+	EraShort
+	//EraSigned writes "3" or "-3", like ShortDate.
+	EraSigned
+)
+
+//LongDateOptions selects a style for the descriptive date format. The zero value produces the same result as LongDate, such as "Thursday, 28 Mendel, 3 After Tranquility".
+type LongDateOptions struct {
+	//Ordinal writes the day of the month as an ordinal number, such as "28th".
+	Ordinal bool
+	//SpellNumbers writes the day of the month and the year in words, such as "twenty-eight" or "twenty-eighth".
+	SpellNumbers bool
+	//AbbreviateWeekday writes the first three letters of the day of the week, such as "Thu".
+	AbbreviateWeekday bool
+	//AbbreviateMonth writes the first three letters of the month, such as "Men".
+	AbbreviateMonth bool
+	//Era selects how the year is written.
+	Era EraStyle
+	//Gregorian appends the Gregorian equivalent in parentheses, such as " (19 July 1972)".
+	Gregorian bool
+	//ThousandsSeparator is inserted between groups of three digits of years written as numbers, such as "," in "12,345". Empty means no separator.
+	ThousandsSeparator string
+}
+
+//Format returns d in the style selected by o.
+func (o LongDateOptions) Format(d TqDate) string {
+	var buf [128]byte
+	return string(o.Append(buf[:0], d))
+}
+
+//Append appends d in the style selected by o to b and returns the extended buffer. With the zero LongDateOptions it is equivalent to AppendLongDate.
+func (o LongDateOptions) Append(b []byte, d TqDate) []byte {
+	switch {
+	case d.Day == MoonLandingDay:
+		b = append(b, DayName(d.Day)...)
+		return o.appendGregorian(b, d)
+	case d.Day < 0:
+		b = append(b, DayName(d.Day)...)
+	default:
+		b = append(b, abbreviate(WeekdayName(d.Weekday()), o.AbbreviateWeekday)...)
+		b = append(b, ", "...)
+		b = o.appendDay(b, clockModulo(d.Day, tqMonthLen))
+		b = append(b, ' ')
+		b = append(b, abbreviate(monthName(d.Month), o.AbbreviateMonth)...)
+	}
+	b = append(b, ", "...)
+	b = o.appendYear(b, d.Year)
+	return o.appendGregorian(b, d)
+}
+
+//abbreviate returns the first three letters of name if abbr is true, and name otherwise.
+func abbreviate(name string, abbr bool) string {
+	if abbr && len(name) > 3 {
+		return name[:3]
+	}
+	return name
+}
+
+func (o LongDateOptions) appendDay(b []byte, day int) []byte {
+	switch {
+	case o.SpellNumbers && o.Ordinal:
+		return appendSpelledOrdinal(b, int64(day))
+	case o.SpellNumbers:
+		return appendSpelled(b, int64(day))
+	case o.Ordinal:
+		b = strconv.AppendInt(b, int64(day), 10)
+		return append(b, ordinalSuffix(int64(day))...)
+	}
+	return strconv.AppendInt(b, int64(day), 10)
+}
+
+//appendYear appends y in the style selected by o. Years outside MinYear to MaxYear belong to no valid date, and might not survive being negated or spelled out, so they are written as signed numbers without an era.
+func (o LongDateOptions) appendYear(b []byte, y int64) []byte {
+	if y < MinYear || y > MaxYear {
+		return strconv.AppendInt(b, y, 10)
+	}
+	n := y
+	if n < 0 && o.Era != EraSigned {
+		n = -n
+	}
+	switch {
+	case o.SpellNumbers && n < 0:
+		b = append(b, "minus "...)
+		b = appendSpelled(b, -n)
+	case o.SpellNumbers:
+		b = appendSpelled(b, n)
+	default:
+		b = appendGrouped(b, n, o.ThousandsSeparator)
+	}
+	switch {
+	case o.Era == EraShort && y < 0:
+		return append(b, " BT"...)
+	case o.Era == EraShort:
+		return append(b, " AT"...)
+	case o.Era == EraLong && y < 0:
+		return append(b, " Before Tranquility"...)
+	case o.Era == EraLong:
+		return append(b, " After Tranquility"...)
+	}
+	return b
+}
+
+//appendGregorian appends the Gregorian equivalent of d in parentheses if it was requested. Nothing is appended for invalid dates.
+func (o LongDateOptions) appendGregorian(b []byte, d TqDate) []byte {
+	if !o.Gregorian {
+		return b
+	}
+	gy, gyd, err := d.Gregorian()
+	if err != nil {
+		return b
+	}
+	m, day := gMonthDay(gy, gyd)
+	b = append(b, " ("...)
+	b = strconv.AppendInt(b, int64(day), 10)
+	b = append(b, ' ')
+	b = append(b, m.String()...)
+	b = append(b, ' ')
+	b = appendGrouped(b, gy, o.ThousandsSeparator)
+	return append(b, ')')
+}
+
+//gMonthDay returns the Gregorian month and day of month of a normalized Gregorian year and day of year. Only the month and day are taken from time.Date, so that years beyond the range of time.Time are supported.
+func gMonthDay(gy int64, gyd int) (time.Month, int) {
+	template := 2001
+	if gLeapYear(gy) {
+		template = 2000
+	}
+	_, m, day := time.Date(template, time.January, gyd, 0, 0, 0, 0, time.UTC).Date()
+	return m, day
+}
+
+//appendGrouped appends n in decimal, with sep between groups of three digits.
+func appendGrouped(b []byte, n int64, sep string) []byte {
+	if sep == "" || (n < 1000 && n > -1000) {
+		return strconv.AppendInt(b, n, 10)
+	}
+	var digits [24]byte
+	s := strconv.AppendInt(digits[:0], n, 10)
+	if s[0] == '-' {
+		b = append(b, '-')
+		s = s[1:]
+	}
+	for i, c := range s {
+		if i > 0 && (len(s)-i)%3 == 0 {
+			b = append(b, sep...)
+		}
+		b = append(b, c)
+	}
+	return b
+}
+
+//ordinalSuffix returns the English ordinal suffix of n, such as "st" for 21 and "th" for 11.
+func ordinalSuffix(n int64) string {
+	if n%100 >= 11 && n%100 <= 13 {
+		return "th"
+	}
+	switch n % 10 {
+	case 1:
+		return "st"
+	case 2:
+		return "nd"
+	case 3:
+		return "rd"
+	}
+	return "th"
+}
+
+var smallNumbers = [20]string{"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine", "ten", "eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen"}
+
+var tens = [10]string{"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety"}
+
+var scales = []struct {
+	value int64
+	name  string
+}{
+	{1000000000000000000, "quintillion"},
+	{1000000000000000, "quadrillion"},
+	{1000000000000, "trillion"},
+	{1000000000, "billion"},
+	{1000000, "million"},
+	{1000, "thousand"},
+	{100, "hundred"},
+}
+
+//appendSpelled appends the non-negative number n in English words, such as "one thousand nine hundred sixty-nine".
+func appendSpelled(b []byte, n int64) []byte {
+	for _, s := range scales {
+		if n >= s.value {
+			b = appendSpelled(b, n/s.value)
+			b = append(b, ' ')
+			b = append(b, s.name...)
+			if n %= s.value; n == 0 {
+				return b
+			}
+			b = append(b, ' ')
+		}
+	}
+	if n < 20 {
+		return append(b, smallNumbers[n]...)
+	}
+	b = append(b, tens[n/10]...)
+	if n%10 != 0 {
+		b = append(b, '-')
+		b = append(b, smallNumbers[n%10]...)
+	}
+	return b
+}
+
+//irregularOrdinals maps the final word of a spelled number to its ordinal form, where adding "th" is not enough.
+var irregularOrdinals = map[string]string{
+	"one":    "first",
+	"two":    "second",
+	"three":  "third",
+	"five":   "fifth",
+	"eight":  "eighth",
+	"nine":   "ninth",
+	"twelve": "twelfth",
+}
+
+//appendSpelledOrdinal appends the non-negative number n as an English ordinal in words, such as "twenty-eighth".
+func appendSpelledOrdinal(b []byte, n int64) []byte {
+	start := len(b)
+	b = appendSpelled(b, n)
+	last := start
+	for i := start; i < len(b); i++ {
+		if b[i] == ' ' || b[i] == '-' {
+			last = i + 1
+		}
+	}
+	word := string(b[last:])
+	if ordinal, ok := irregularOrdinals[word]; ok {
+		return append(b[:last], ordinal...)
+	}
+	if word[len(word)-1] == 'y' {
+		return append(b[:len(b)-1], "ieth"...)
+	}
+	return append(b, "th"...)
+}
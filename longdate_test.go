@@ -0,0 +1,57 @@
+package tqtime
+
+import (
+	"math"
+	"testing"
+)
+
+var longOptionTests = []struct {
+	date   TqDate
+	opts   LongDateOptions
+	output string
+}{
+	{TqDate{3, Mendel, 28}, LongDateOptions{}, "Thursday, 28 Mendel, 3 After Tranquility"},
+	{TqDate{3, Mendel, 28}, LongDateOptions{Ordinal: true}, "Thursday, 28th Mendel, 3 After Tranquility"},
+	{TqDate{3, Mendel, 1}, LongDateOptions{Ordinal: true}, "Friday, 1st Mendel, 3 After Tranquility"},
+	{TqDate{3, Mendel, 12}, LongDateOptions{Ordinal: true}, "Tuesday, 12th Mendel, 3 After Tranquility"},
+	{TqDate{3, Mendel, 22}, LongDateOptions{Ordinal: true}, "Friday, 22nd Mendel, 3 After Tranquility"},
+	{TqDate{3, Mendel, 23}, LongDateOptions{Ordinal: true}, "Saturday, 23rd Mendel, 3 After Tranquility"},
+	{TqDate{3, Mendel, 28}, LongDateOptions{SpellNumbers: true}, "Thursday, twenty-eight Mendel, three After Tranquility"},
+	{TqDate{3, Mendel, 28}, LongDateOptions{SpellNumbers: true, Ordinal: true}, "Thursday, twenty-eighth Mendel, three After Tranquility"},
+	{TqDate{3, Mendel, 12}, LongDateOptions{SpellNumbers: true, Ordinal: true}, "Tuesday, twelfth Mendel, three After Tranquility"},
+	{TqDate{3, Mendel, 20}, LongDateOptions{SpellNumbers: true, Ordinal: true}, "Wednesday, twentieth Mendel, three After Tranquility"},
+	{TqDate{3, Mendel, 3}, LongDateOptions{SpellNumbers: true, Ordinal: true}, "Sunday, third Mendel, three After Tranquility"},
+	{TqDate{3, Mendel, 28}, LongDateOptions{AbbreviateWeekday: true, AbbreviateMonth: true}, "Thu, 28 Men, 3 After Tranquility"},
+	{TqDate{-3, Mendel, 28}, LongDateOptions{Era: EraShort}, "Thursday, 28 Mendel, 3 BT"},
+	{TqDate{3, Mendel, 28}, LongDateOptions{Era: EraShort}, "Thursday, 28 Mendel, 3 AT"},
+	{TqDate{-3, Mendel, 28}, LongDateOptions{Era: EraSigned}, "Thursday, 28 Mendel, -3"},
+	{TqDate{-3, Mendel, 28}, LongDateOptions{Era: EraSigned, SpellNumbers: true}, "Thursday, twenty-eight Mendel, minus three"},
+	{TqDate{3, Mendel, 28}, LongDateOptions{Gregorian: true}, "Thursday, 28 Mendel, 3 After Tranquility (19 July 1972)"},
+	{TqDate{31, SpecialDay, AldrinDay}, LongDateOptions{Gregorian: true, Era: EraShort}, "Aldrin Day, 31 AT (29 February 2000)"},
+	{TqDate{0, SpecialDay, MoonLandingDay}, LongDateOptions{Gregorian: true}, "Moon Landing Day (20 July 1969)"},
+	{TqDate{-1234567, SpecialDay, ArmstrongDay}, LongDateOptions{ThousandsSeparator: ","}, "Armstrong Day, 1,234,567 Before Tranquility"},
+	{TqDate{-1234567, SpecialDay, ArmstrongDay}, LongDateOptions{ThousandsSeparator: " ", Era: EraSigned}, "Armstrong Day, -1 234 567"},
+	{TqDate{1000000000000, Archimedes, 1}, LongDateOptions{ThousandsSeparator: ",", Gregorian: true, Era: EraShort}, "Friday, 1 Archimedes, 1,000,000,000,000 AT (21 July 1,000,000,001,968)"},
+	{TqDate{1969, Archimedes, 1}, LongDateOptions{SpellNumbers: true}, "Friday, one Archimedes, one thousand nine hundred sixty-nine After Tranquility"},
+	{TqDate{999, Archimedes, 1}, LongDateOptions{ThousandsSeparator: ","}, "Friday, 1 Archimedes, 999 After Tranquility"},
+	{TqDate{math.MinInt64, Archimedes, 1}, LongDateOptions{SpellNumbers: true}, "Friday, one Archimedes, -9223372036854775808"},
+	{TqDate{math.MinInt64, Archimedes, 1}, LongDateOptions{}, "Friday, 1 Archimedes, -9223372036854775808"},
+	{TqDate{math.MaxInt64, SpecialDay, ArmstrongDay}, LongDateOptions{SpellNumbers: true, ThousandsSeparator: ","}, "Armstrong Day, 9223372036854775807"},
+}
+
+func TestLongDateOptions(t *testing.T) {
+	for _, tt := range longOptionTests {
+		if actual := tt.opts.Format(tt.date); actual != tt.output {
+			t.Errorf("%+v.Format(%+v) = %q; expected %q", tt.opts, tt.date, actual, tt.output)
+		}
+	}
+}
+
+func TestSpelledOrdinals(t *testing.T) {
+	expected := []string{"first", "second", "third", "fourth", "fifth", "sixth", "seventh", "eighth", "ninth", "tenth", "eleventh", "twelfth", "thirteenth", "fourteenth", "fifteenth", "sixteenth", "seventeenth", "eighteenth", "nineteenth", "twentieth", "twenty-first", "twenty-second", "twenty-third", "twenty-fourth", "twenty-fifth", "twenty-sixth", "twenty-seventh", "twenty-eighth"}
+	for i, e := range expected {
+		if actual := string(appendSpelledOrdinal(nil, int64(i+1))); actual != e {
+			t.Errorf("Ordinal of %d spelled %q; expected %q", i+1, actual, e)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package tqtime
+
+import (
+	"errors"
+	"time"
+)
+
+//Rule describes a recurring event in Tranquility terms, similar to an iCalendar RRULE. Every field restricts the days on which the rule occurs, and the zero value of a field places no restriction. For example:
+//
+//	Rule{Day: 14}                         every month on day 14
+//	Rule{Month: Kepler, Weekday: Tuesday} every Tuesday of Kepler
+//	Rule{Week: 2, Weekday: Friday}        every 2nd Friday
+//	Rule{Day: ArmstrongDay}               every Armstrong Day
+//	Rule{Day: AldrinDay}                  every Aldrin Day
+//
+//Special days are not part of any month or week, so they only match a rule whose Day is their constant, and whose Month, Week and Weekday are zero. A rule never matches Moon Landing Day, which only happened once.
+type Rule struct {
+	//Month restricts the rule to a single month. SpecialDay matches every month.
+	Month TqMonth
+	//Day is either a day of the month in range [1,28], ArmstrongDay or AldrinDay. Zero matches every day of the month.
+	Day int
+	//Weekday restricts the rule to a single day of the week. SpecialWeekday matches every day of the week.
+	Weekday TqWeekday
+	//Week restricts the rule to the nth week of the month, in range [1,4]. Zero matches every week.
+	Week int
+	//At is the wall clock time of day of each occurrence, written as a duration such as 9*time.Hour for 09:00. On days when the clocks change it is still read from the clock, not counted from midnight, so a 09:00 meeting stays at 09:00.
+	At time.Duration
+	//Count is the maximum number of occurrences, counted from the start of the expansion. Zero means no limit.
+	Count int
+	//Until is the last instant at which the rule may occur, inclusive. The zero Time means no limit.
+	Until time.Time
+	//Exclude lists days on which the rule does not occur. As with iCalendar EXDATE, excluded days still count towards Count.
+	Exclude []TqDate
+}
+
+//ErrRuleNeverMatches is returned when the restrictions of a Rule contradict each other, such as Day 14 and Weekday Friday.
+var ErrRuleNeverMatches = errors.New("tqtime: rule never matches any day")
+
+//ErrUnbounded is returned when expanding a Rule would produce an endless list of occurrences.
+var ErrUnbounded = errors.New("tqtime: rule expansion has no end")
+
+//Matches returns true if r occurs on d, ignoring Count, Until and Exclude.
+func (r Rule) Matches(d TqDate) bool {
+	if d.Day < 0 {
+		return d.Day == r.Day && d.Day != MoonLandingDay && r.Month == SpecialDay && r.Week == 0 && r.Weekday == SpecialWeekday
+	}
+	switch {
+	case r.Month != SpecialDay && r.Month != d.Month:
+		return false
+	case r.Day != 0 && r.Day != d.Day:
+		return false
+	case r.Weekday != SpecialWeekday && r.Weekday != d.Weekday():
+		return false
+	case r.Week != 0 && r.Week != (d.Day-1)/7+1:
+		return false
+	}
+	return true
+}
+
+//Validate returns nil if r is well formed and occurs on at least one day.
+func (r Rule) Validate() error {
+	switch {
+	case r.Month < SpecialDay || r.Month > Mendel:
+		return ErrInvalidDate
+	case r.Weekday < SpecialWeekday || r.Weekday > Thursday:
+		return ErrInvalidDate
+	case r.Week < 0 || r.Week > tqMonthLen/7:
+		return ErrInvalidDate
+	case r.Day != ArmstrongDay && r.Day != AldrinDay && (r.Day < 0 || r.Day > tqMonthLen):
+		return ErrInvalidDate
+	case r.At < 0 || r.At >= 24*time.Hour:
+		return errors.New("tqtime: rule time of day is not within a day")
+	}
+	if r.Day < 0 {
+		if r.Matches(TqDate{Year: 31, Month: SpecialDay, Day: r.Day}) {
+			return nil
+		}
+		return ErrRuleNeverMatches
+	}
+	//Every year has the same months and weeks, so if no day of one year matches, no day ever will.
+	for m := Archimedes; m <= Mendel; m++ {
+		for day := 1; day <= tqMonthLen; day++ {
+			if r.Matches(TqDate{Year: 1, Month: m, Day: day}) {
+				return nil
+			}
+		}
+	}
+	return ErrRuleNeverMatches
+}
+
+//excluded returns true if d is listed in r.Exclude.
+func (r Rule) excluded(d TqDate) bool {
+	for _, e := range r.Exclude {
+		if e == d {
+			return true
+		}
+	}
+	return false
+}
+
+//Expand returns the instants at which r occurs, in the location of start, from start (inclusive) until end (exclusive). Expansion also stops once r.Count occurrences have been produced or r.Until has passed. end may be the zero Time if r.Count or r.Until is set, otherwise ErrUnbounded is returned.
+func (r Rule) Expand(start, end time.Time) ([]time.Time, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	if end.IsZero() && r.Until.IsZero() && r.Count == 0 {
+		return nil, ErrUnbounded
+	}
+	loc := start.Location()
+	var result []time.Time
+	count := 0
+	for d := FromTime(start); ; {
+		midnight, err := d.Time(loc)
+		if err != nil {
+			return result, err
+		}
+		gy, gm, gd := midnight.Date()
+		t := time.Date(gy, gm, gd, int(r.At/time.Hour), int(r.At%time.Hour/time.Minute), int(r.At%time.Minute/time.Second), int(r.At%time.Second), loc)
+		if (!end.IsZero() && !t.Before(end)) || (!r.Until.IsZero() && t.After(r.Until)) {
+			return result, nil
+		}
+		if !t.Before(start) && r.Matches(d) {
+			count++
+			if !r.excluded(d) {
+				result = append(result, t)
+			}
+			if count == r.Count {
+				return result, nil
+			}
+		}
+		if d, err = d.AddDays(1); err != nil {
+			return result, err
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package tqtime
+
+import (
+	"testing"
+	"time"
+)
+
+func shortDates(times []time.Time) []string {
+	s := make([]string, len(times))
+	for i, t := range times {
+		s[i] = FromTime(t).ShortDate()
+	}
+	return s
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+var ruleStart = time.Date(1999, time.July, 21, 0, 0, 0, 0, time.UTC) //1 Archimedes 31 AT
+
+var ruleTests = []struct {
+	name   string
+	rule   Rule
+	output []string
+}{
+	{"every month on day 14", Rule{Day: 14, Count: 3}, []string{"14A 31", "14B 31", "14C 31"}},
+	{"every Tuesday of Kepler", Rule{Month: Kepler, Weekday: Tuesday, Count: 5}, []string{"05K 31", "12K 31", "19K 31", "26K 31", "05K 32"}},
+	{"every 2nd Friday", Rule{Week: 2, Weekday: Friday, Count: 3}, []string{"08A 31", "08B 31", "08C 31"}},
+	{"every Armstrong Day", Rule{Day: ArmstrongDay, Count: 3}, []string{"ARM 31", "ARM 32", "ARM 33"}},
+	{"every Aldrin Day", Rule{Day: AldrinDay, Count: 3}, []string{"ALD 31", "ALD 35", "ALD 39"}},
+	{"4th week of Hippocrates", Rule{Month: Hippocrates, Week: 4, Count: 8}, []string{"22H 31", "23H 31", "24H 31", "25H 31", "26H 31", "27H 31", "28H 31", "22H 32"}},
+	{"until", Rule{Day: 1, Until: time.Date(1999, time.October, 13, 0, 0, 0, 0, time.UTC)}, []string{"01A 31", "01B 31", "01C 31", "01D 31"}},
+	{"exclusions", Rule{Day: 1, Count: 4, Exclude: []TqDate{{31, Brahe, 1}}}, []string{"01A 31", "01C 31", "01D 31"}},
+}
+
+func TestRuleExpand(t *testing.T) {
+	for _, tt := range ruleTests {
+		times, err := tt.rule.Expand(ruleStart, time.Time{})
+		if err != nil {
+			t.Errorf("%s: Expand returned %v", tt.name, err)
+			continue
+		}
+		if actual := shortDates(times); !equalStrings(actual, tt.output) {
+			t.Errorf("%s: Expand gave %v; expected %v", tt.name, actual, tt.output)
+		}
+	}
+}
+
+func TestRuleExpandTimeOfDay(t *testing.T) {
+	loc := time.FixedZone("UTC-8", -8*60*60)
+	start := time.Date(1999, time.July, 21, 10, 0, 0, 0, loc)
+	r := Rule{Day: 1, At: 9 * time.Hour}
+	end := time.Date(1999, time.September, 15, 9, 0, 0, 0, loc)
+	times, err := r.Expand(start, end)
+	if err != nil {
+		t.Fatalf("Expand returned %v", err)
+	}
+	expected := []time.Time{
+		time.Date(1999, time.August, 18, 9, 0, 0, 0, loc),
+	}
+	if len(times) != len(expected) || !times[0].Equal(expected[0]) || times[0].Location() != loc {
+		t.Errorf("Expand gave %v; expected %v", times, expected)
+	}
+}
+
+func TestRuleExpandClockChange(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	//The clocks went forward on 10 March 2024 and back on 3 November 2024.
+	for _, start := range []time.Time{time.Date(2024, time.March, 9, 0, 0, 0, 0, loc), time.Date(2024, time.November, 2, 0, 0, 0, 0, loc)} {
+		times, err := Rule{At: 9*time.Hour + 30*time.Minute, Count: 3}.Expand(start, time.Time{})
+		if err != nil {
+			t.Fatalf("Expand returned %v", err)
+		}
+		for _, tm := range times {
+			if h, m, _ := tm.Clock(); h != 9 || m != 30 {
+				t.Errorf("Expand gave %v; expected 09:30 every day", tm)
+			}
+		}
+	}
+}
+
+func TestRuleInvalid(t *testing.T) {
+	var invalidRuleTests = []struct {
+		rule Rule
+		err  error
+	}{
+		{Rule{Day: 14, Weekday: Friday, Count: 1}, ErrRuleNeverMatches},
+		{Rule{Day: 3, Week: 2, Count: 1}, ErrRuleNeverMatches},
+		{Rule{Day: ArmstrongDay, Month: Mendel, Count: 1}, ErrRuleNeverMatches},
+		{Rule{Day: MoonLandingDay, Count: 1}, ErrInvalidDate},
+		{Rule{Day: 29, Count: 1}, ErrInvalidDate},
+		{Rule{Week: 5, Count: 1}, ErrInvalidDate},
+		{Rule{Day: 1}, ErrUnbounded},
+	}
+	for _, tt := range invalidRuleTests {
+		if _, err := tt.rule.Expand(ruleStart, time.Time{}); err != tt.err {
+			t.Errorf("Expand of %+v returned %v; expected %v", tt.rule, err, tt.err)
+		}
+	}
+}
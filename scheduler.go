@@ -0,0 +1,121 @@
+package tqtime
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+//Scheduler runs callbacks at the times given by cron expressions in the Tranquility calendar. See ParseCron for the syntax.
+type Scheduler struct {
+	clock Clock
+	loc   *time.Location
+	mu    sync.Mutex
+	jobs  []*cronJob
+	//wake is signalled by Add, so that Run notices jobs that fire before the time it is waiting for.
+	wake chan struct{}
+}
+
+type cronJob struct {
+	schedule *CronSchedule
+	fn       func(time.Time)
+	next     time.Time
+}
+
+//NewScheduler returns a Scheduler which reads the time from clock, and interprets cron expressions in loc.
+func NewScheduler(clock Clock, loc *time.Location) *Scheduler {
+	return &Scheduler{clock: clock, loc: loc, wake: make(chan struct{}, 1)}
+}
+
+//Add parses the cron expression expr and adds a job which calls fn with the scheduled time whenever it fires. Jobs may be added while the Scheduler is running.
+func (s *Scheduler) Add(expr string, fn func(time.Time)) error {
+	schedule, err := ParseCron(expr)
+	if err != nil {
+		return err
+	}
+	next, err := schedule.Next(s.clock.Now().In(s.loc))
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.jobs = append(s.jobs, &cronJob{schedule: schedule, fn: fn, next: next})
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	s.mu.Unlock()
+	return nil
+}
+
+//NextFire returns the next time at which any job fires. The result is false if there are no jobs.
+func (s *Scheduler) NextFire() (time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var next time.Time
+	for _, j := range s.jobs {
+		if next.IsZero() || j.next.Before(next) {
+			next = j.next
+		}
+	}
+	return next, !next.IsZero()
+}
+
+//due returns the jobs with the earliest fire time, if that time is not after t, and advances each of them to its following fire time. Jobs with no following fire time are removed.
+func (s *Scheduler) due(t time.Time) []cronJob {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var earliest time.Time
+	for _, j := range s.jobs {
+		if earliest.IsZero() || j.next.Before(earliest) {
+			earliest = j.next
+		}
+	}
+	if earliest.IsZero() || earliest.After(t) {
+		return nil
+	}
+	var due []cronJob
+	remaining := s.jobs[:0]
+	for _, j := range s.jobs {
+		if j.next.Equal(earliest) {
+			due = append(due, *j)
+			next, err := j.schedule.Next(j.next)
+			if err != nil {
+				continue
+			}
+			j.next = next
+		}
+		remaining = append(remaining, j)
+	}
+	s.jobs = remaining
+	return due
+}
+
+//Run calls the callbacks of the jobs as they fire, until ctx is cancelled. Callbacks are called one at a time from the goroutine running Run, in order of their fire times, and in the order the jobs were added when several jobs fire at once. If the Scheduler falls behind, every missed fire time is delivered in turn. Run reads the clock at least every 15 minutes, so jobs which should have fired while the computer was suspended, or before the clock was changed, fire soon after. Run returns the error of ctx.
+func (s *Scheduler) Run(ctx context.Context) error {
+	//idle is how long to wait when there are no jobs. Jobs added during a wait end it early.
+	const idle = time.Minute
+	for {
+		wait := idle
+		if next, ok := s.NextFire(); ok {
+			wait = next.Sub(s.clock.Now())
+		}
+		if wait > maxWait {
+			wait = maxWait
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-s.wake:
+			continue
+		case <-s.clock.After(wait):
+		}
+		for due := s.due(s.clock.Now()); len(due) > 0; due = s.due(s.clock.Now()) {
+			for _, j := range due {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				j.fn(j.next)
+			}
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package tqtime
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestScheduler(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, time.July, 18, 0, 0, 0, 0, time.UTC))
+	s := NewScheduler(clock, time.UTC)
+	fired := make(chan string, 10)
+	if err := s.Add("0 9 * * Fri", func(t time.Time) { fired <- "Friday " + FromTime(t).ShortDate() }); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Add("0 0 ARM * *", func(t time.Time) { fired <- "Armstrong " + FromTime(t).ShortDate() }); err != nil {
+		t.Fatal(err)
+	}
+	if next, ok := s.NextFire(); !ok || !next.Equal(time.Date(2024, time.July, 20, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("NextFire = %v, %v", next, ok)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- s.Run(ctx) }()
+
+	expected := []string{"Armstrong ARM 55", "Friday 01A 56", "Friday 08A 56"}
+	clock.BlockUntilWaiting(1)
+	clock.Advance(11 * 24 * time.Hour)
+	for _, e := range expected {
+		select {
+		case actual := <-fired:
+			if actual != e {
+				t.Errorf("Scheduler fired %q; expected %q", actual, e)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Scheduler did not fire %q", e)
+		}
+	}
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Errorf("Run returned %v; expected %v", err, context.Canceled)
+	}
+	if len(fired) != 0 {
+		t.Errorf("Scheduler fired %q too early", <-fired)
+	}
+}
+
+func TestSchedulerAddWhileWaiting(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, time.July, 18, 0, 0, 0, 0, time.UTC))
+	s := NewScheduler(clock, time.UTC)
+	fired := make(chan string, 10)
+	if err := s.Add("0 0 ARM * *", func(t time.Time) { fired <- "Armstrong" }); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go s.Run(ctx)
+
+	//Run is waiting for Armstrong Day, two days away, when a job firing in an hour is added.
+	clock.BlockUntilWaiting(1)
+	if err := s.Add("0 1 * * *", func(t time.Time) { fired <- "hourly " + t.Format(time.Kitchen) }); err != nil {
+		t.Fatal(err)
+	}
+	clock.BlockUntilWaiting(2)
+	clock.Advance(time.Hour)
+	select {
+	case actual := <-fired:
+		if actual != "hourly 1:00AM" {
+			t.Errorf("Scheduler fired %q; expected the added job", actual)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the job added while Run was waiting did not fire on time")
+	}
+}
+
+func TestSchedulerWakes(t *testing.T) {
+	start := time.Date(2024, time.July, 18, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	s := NewScheduler(clock, time.UTC)
+	fired := make(chan time.Time, 10)
+	if err := s.Add("0 0 ARM * *", func(t time.Time) { fired <- t }); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go s.Run(ctx)
+
+	//Armstrong Day is two days away, but Run must still read the clock every maxWait.
+	for i := 1; i <= 3; i++ {
+		if deadline := waitingUntil(clock); !deadline.Equal(start.Add(time.Duration(i) * maxWait)) {
+			t.Fatalf("Run waited until %v; expected %v", deadline, start.Add(time.Duration(i)*maxWait))
+		}
+		clock.Advance(maxWait)
+	}
+	if len(fired) != 0 {
+		t.Errorf("Scheduler fired at %v too early", <-fired)
+	}
+}
@@ -0,0 +1,118 @@
+package tqtime
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+//Schema is a machine readable description of the Tranquility calendar, for clients such as web front ends which would otherwise hard-code it. It is built from the same constants as the rest of this package, and can be encoded with encoding/json.
+type Schema struct {
+	Months      []MonthSchema      `json:"months"`
+	Week        WeekSchema         `json:"week"`
+	SpecialDays []SpecialDaySchema `json:"specialDays"`
+}
+
+//MonthSchema describes a Tranquility month. The Gregorian dates are given as "MM-DD" for the first and last day of the month, in a common year and in a leap year. In leap years Aldrin Day falls between the first and last days of Hippocrates, but it is not counted in Days.
+type MonthSchema struct {
+	Number     int           `json:"number"`
+	Name       string        `json:"name"`
+	Letter     string        `json:"letter"`
+	Days       int           `json:"days"`
+	CommonYear GregorianDays `json:"commonYear"`
+	LeapYear   GregorianDays `json:"leapYear"`
+}
+
+//GregorianDays holds the first and last Gregorian days of a period, inclusive, as "MM-DD".
+type GregorianDays struct {
+	First string `json:"first"`
+	Last  string `json:"last"`
+}
+
+//WeekSchema describes the Tranquility week. Every month starts on the first day of the week, so each month has the same weeks.
+type WeekSchema struct {
+	Days          int             `json:"days"`
+	WeeksPerMonth int             `json:"weeksPerMonth"`
+	Weekdays      []WeekdaySchema `json:"weekdays"`
+}
+
+//WeekdaySchema describes a day of the Tranquility week.
+type WeekdaySchema struct {
+	Number int    `json:"number"`
+	Name   string `json:"name"`
+}
+
+//SpecialDaySchema describes a day which is not part of any month or week. Constant is the value returned by the Day function on that day, and Code is the value of DayCode. Gregorian is the date of the day, as "MM-DD" for recurring days and "YYYY-MM-DD" for Moon Landing Day.
+type SpecialDaySchema struct {
+	Name      string `json:"name"`
+	Code      string `json:"code"`
+	Constant  int    `json:"constant"`
+	Gregorian string `json:"gregorian"`
+	Placement string `json:"placement"`
+}
+
+//gMonthDayString returns the Gregorian month and day of d as "MM-DD".
+func (d TqDate) gMonthDayString() string {
+	gy, gyd, _ := d.Gregorian()
+	m, day := gMonthDay(gy, gyd)
+	return fmt.Sprintf("%02d-%02d", int(m), day)
+}
+
+//CalendarSchema returns the description of the Tranquility calendar.
+func CalendarSchema() Schema {
+	//Year 2 After Tranquility is a common year, and year 3 is a leap year.
+	const commonYear, leapYear int64 = 2, 3
+	var s Schema
+	for m := Archimedes; m <= Mendel; m++ {
+		s.Months = append(s.Months, MonthSchema{
+			Number: int(m),
+			Name:   m.String(),
+			Letter: MonthLetter(m),
+			Days:   tqMonthLen,
+			CommonYear: GregorianDays{
+				First: TqDate{commonYear, m, 1}.gMonthDayString(),
+				Last:  TqDate{commonYear, m, tqMonthLen}.gMonthDayString(),
+			},
+			LeapYear: GregorianDays{
+				First: TqDate{leapYear, m, 1}.gMonthDayString(),
+				Last:  TqDate{leapYear, m, tqMonthLen}.gMonthDayString(),
+			},
+		})
+	}
+
+	s.Week = WeekSchema{Days: 7, WeeksPerMonth: tqMonthLen / 7}
+	for wd := Friday; wd <= Thursday; wd++ {
+		s.Week.Weekdays = append(s.Week.Weekdays, WeekdaySchema{Number: int(wd), Name: wd.String()})
+	}
+
+	mld := TqDate{Month: SpecialDay, Day: MoonLandingDay}
+	gy, _, _ := mld.Gregorian()
+	s.SpecialDays = []SpecialDaySchema{
+		{
+			Name:      DayName(ArmstrongDay),
+			Code:      DayCode(ArmstrongDay),
+			Constant:  ArmstrongDay,
+			Gregorian: armstrongDayOf(commonYear).gMonthDayString(),
+			Placement: "The last day of every year, after 28 Mendel, except 1 Before Tranquility.",
+		},
+		{
+			Name:      DayName(AldrinDay),
+			Code:      DayCode(AldrinDay),
+			Constant:  AldrinDay,
+			Gregorian: aldrinDayOf(leapYear).gMonthDayString(),
+			Placement: "Between 27 and 28 Hippocrates in leap years, which are the years containing a Gregorian 29 February.",
+		},
+		{
+			Name:      DayName(MoonLandingDay),
+			Code:      DayCode(MoonLandingDay),
+			Constant:  MoonLandingDay,
+			Gregorian: fmt.Sprintf("%d-%s", gy, mld.gMonthDayString()),
+			Placement: "Between 28 Mendel 1 Before Tranquility and 1 Archimedes 1 After Tranquility. It is not part of any year, and happens only once.",
+		},
+	}
+	return s
+}
+
+//SchemaJSON returns CalendarSchema encoded as indented JSON.
+func SchemaJSON() ([]byte, error) {
+	return json.MarshalIndent(CalendarSchema(), "", "  ")
+}
@@ -0,0 +1,58 @@
+package tqtime
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCalendarSchemaMonths(t *testing.T) {
+	s := CalendarSchema()
+	if len(s.Months) != int(Mendel) {
+		t.Fatalf("Schema has %d months", len(s.Months))
+	}
+	var monthTests = []struct {
+		month  TqMonth
+		common GregorianDays
+		leap   GregorianDays
+	}{
+		{Archimedes, GregorianDays{"07-21", "08-17"}, GregorianDays{"07-21", "08-17"}},
+		{Galileo, GregorianDays{"01-05", "02-01"}, GregorianDays{"01-05", "02-01"}},
+		{Hippocrates, GregorianDays{"02-02", "03-01"}, GregorianDays{"02-02", "03-01"}},
+		{Imhotep, GregorianDays{"03-02", "03-29"}, GregorianDays{"03-02", "03-29"}},
+		{Mendel, GregorianDays{"06-22", "07-19"}, GregorianDays{"06-22", "07-19"}},
+	}
+	for _, tt := range monthTests {
+		m := s.Months[tt.month-1]
+		if m.Name != tt.month.String() || m.Letter != MonthLetter(tt.month) || m.Number != int(tt.month) || m.Days != 28 {
+			t.Errorf("Schema of %v: %+v", tt.month, m)
+		}
+		if m.CommonYear != tt.common || m.LeapYear != tt.leap {
+			t.Errorf("Schema of %v: common %+v, leap %+v; expected %+v, %+v", tt.month, m.CommonYear, m.LeapYear, tt.common, tt.leap)
+		}
+	}
+}
+
+func TestCalendarSchemaJSON(t *testing.T) {
+	b, err := SchemaJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var s Schema
+	if err := json.Unmarshal(b, &s); err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Week.Weekdays) != 7 || s.Week.Weekdays[0].Name != "Friday" || s.Week.WeeksPerMonth != 4 {
+		t.Errorf("Schema week decoded as %+v", s.Week)
+	}
+	expected := []SpecialDaySchema{
+		{Name: "Armstrong Day", Code: "ARM", Constant: ArmstrongDay, Gregorian: "07-20"},
+		{Name: "Aldrin Day", Code: "ALD", Constant: AldrinDay, Gregorian: "02-29"},
+		{Name: "Moon Landing Day", Code: "MNL", Constant: MoonLandingDay, Gregorian: "1969-07-20"},
+	}
+	for i, e := range expected {
+		e.Placement = s.SpecialDays[i].Placement
+		if s.SpecialDays[i] != e || e.Placement == "" {
+			t.Errorf("Special day %d decoded as %+v; expected %+v", i, s.SpecialDays[i], e)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package tqtime
+
+import "time"
+
+//Occurrence is a day found by one of the Next or Prev functions, given both as a Tranquility date and as midnight at the start of that day in the Gregorian calendar.
+type Occurrence struct {
+	Date TqDate
+	Time time.Time
+}
+
+//occurrence converts the result of a search into an Occurrence in loc.
+func occurrence(d TqDate, err error, loc *time.Location) (Occurrence, error) {
+	if err != nil {
+		return Occurrence{}, err
+	}
+	t, err := d.Time(loc)
+	if err != nil {
+		return Occurrence{}, err
+	}
+	return Occurrence{Date: d, Time: t}, nil
+}
+
+//searchYears calls candidate with the year of from and the following years (dir = 1) or preceding years (dir = -1), and returns the first valid candidate strictly after or before from. candidate must produce a valid date within a few years, or the search will not end.
+func searchYears(from TqDate, dir int64, candidate func(y int64) TqDate) (TqDate, error) {
+	for y := from.Year; ; y += dir {
+		c := candidate(y)
+		err := c.Valid()
+		if err == ErrOutOfRange {
+			return TqDate{}, err
+		}
+		if err == nil && int64(c.Compare(from))*dir > 0 {
+			return c, nil
+		}
+	}
+}
+
+func armstrongDayOf(y int64) TqDate {
+	return TqDate{Year: y, Month: SpecialDay, Day: ArmstrongDay}
+}
+
+func aldrinDayOf(y int64) TqDate {
+	return TqDate{Year: y, Month: SpecialDay, Day: AldrinDay}
+}
+
+//NextArmstrongDay returns the first Armstrong Day after the day of from, in the location of from.
+func NextArmstrongDay(from time.Time) (Occurrence, error) {
+	d, err := searchYears(FromTime(from), 1, armstrongDayOf)
+	return occurrence(d, err, from.Location())
+}
+
+//PrevArmstrongDay returns the last Armstrong Day before the day of from, in the location of from.
+func PrevArmstrongDay(from time.Time) (Occurrence, error) {
+	d, err := searchYears(FromTime(from), -1, armstrongDayOf)
+	return occurrence(d, err, from.Location())
+}
+
+//NextAldrinDay returns the first Aldrin Day after the day of from, in the location of from. Leap years are usually 4 years apart, but may be up to 8 years apart around centuries which are not divisible by 400.
+func NextAldrinDay(from time.Time) (Occurrence, error) {
+	d, err := searchYears(FromTime(from), 1, aldrinDayOf)
+	return occurrence(d, err, from.Location())
+}
+
+//PrevAldrinDay returns the last Aldrin Day before the day of from, in the location of from.
+func PrevAldrinDay(from time.Time) (Occurrence, error) {
+	d, err := searchYears(FromTime(from), -1, aldrinDayOf)
+	return occurrence(d, err, from.Location())
+}
+
+//monthDay returns the search candidates for Next and Prev, after checking that m and day name a day of every Tranquility year.
+func monthDay(m TqMonth, day int) (func(y int64) TqDate, error) {
+	if err := (TqDate{Year: 1, Month: m, Day: day}).Valid(); err != nil || m == SpecialDay {
+		return nil, ErrInvalidDate
+	}
+	return func(y int64) TqDate {
+		return TqDate{Year: y, Month: m, Day: day}
+	}, nil
+}
+
+//Next returns the first occurrence of day of month m after the day of from, in the location of from. ErrInvalidDate is returned if m is not a month or day is not in range [1,28].
+func Next(from time.Time, m TqMonth, day int) (Occurrence, error) {
+	candidate, err := monthDay(m, day)
+	if err != nil {
+		return Occurrence{}, err
+	}
+	d, err := searchYears(FromTime(from), 1, candidate)
+	return occurrence(d, err, from.Location())
+}
+
+//Prev returns the last occurrence of day of month m before the day of from, in the location of from. ErrInvalidDate is returned if m is not a month or day is not in range [1,28].
+func Prev(from time.Time, m TqMonth, day int) (Occurrence, error) {
+	candidate, err := monthDay(m, day)
+	if err != nil {
+		return Occurrence{}, err
+	}
+	d, err := searchYears(FromTime(from), -1, candidate)
+	return occurrence(d, err, from.Location())
+}
+
+//searchWeekday steps from the day of from in direction dir until it reaches a day falling on wd. Special days are skipped, since they are not part of any week.
+func searchWeekday(from time.Time, dir int64, wd TqWeekday) (Occurrence, error) {
+	if wd < Friday || wd > Thursday {
+		return Occurrence{}, ErrInvalidDate
+	}
+	d := FromTime(from)
+	for {
+		var err error
+		if d, err = d.AddDays(dir); err != nil {
+			return Occurrence{}, err
+		}
+		if d.Weekday() == wd {
+			return occurrence(d, nil, from.Location())
+		}
+	}
+}
+
+//NextWeekday returns the first day falling on wd after the day of from, in the location of from. ErrInvalidDate is returned if wd is SpecialWeekday or out of range.
+func NextWeekday(from time.Time, wd TqWeekday) (Occurrence, error) {
+	return searchWeekday(from, 1, wd)
+}
+
+//PrevWeekday returns the last day falling on wd before the day of from, in the location of from. ErrInvalidDate is returned if wd is SpecialWeekday or out of range.
+func PrevWeekday(from time.Time, wd TqWeekday) (Occurrence, error) {
+	return searchWeekday(from, -1, wd)
+}
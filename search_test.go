@@ -0,0 +1,64 @@
+package tqtime
+
+import (
+	"testing"
+	"time"
+)
+
+func gregorianDay(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 15, 30, 0, 0, time.UTC)
+}
+
+var searchTests = []struct {
+	name   string
+	search func(time.Time) (Occurrence, error)
+	from   time.Time
+	output string
+}{
+	{"NextArmstrongDay", NextArmstrongDay, gregorianDay(1969, time.July, 19), "ARM 1"},
+	{"NextArmstrongDay", NextArmstrongDay, gregorianDay(1970, time.July, 20), "ARM 2"},
+	{"NextArmstrongDay", NextArmstrongDay, gregorianDay(1968, time.July, 19), "ARM -2"},
+	{"PrevArmstrongDay", PrevArmstrongDay, gregorianDay(1969, time.July, 21), "ARM -2"},
+	{"PrevArmstrongDay", PrevArmstrongDay, gregorianDay(2024, time.July, 20), "ARM 54"},
+	{"NextAldrinDay", NextAldrinDay, gregorianDay(2097, time.March, 1), "ALD 135"},
+	{"NextAldrinDay", NextAldrinDay, gregorianDay(2000, time.February, 28), "ALD 31"},
+	{"NextAldrinDay", NextAldrinDay, gregorianDay(2000, time.February, 29), "ALD 35"},
+	{"PrevAldrinDay", PrevAldrinDay, gregorianDay(2104, time.February, 29), "ALD 127"},
+	{"PrevAldrinDay", PrevAldrinDay, gregorianDay(1969, time.July, 20), "ALD -2"},
+	{"Next 28 Mendel", func(t time.Time) (Occurrence, error) { return Next(t, Mendel, 28) }, gregorianDay(1972, time.July, 19), "28M 4"},
+	{"Next 1 Archimedes", func(t time.Time) (Occurrence, error) { return Next(t, Archimedes, 1) }, gregorianDay(1969, time.July, 1), "01A 1"},
+	{"Prev 1 Archimedes", func(t time.Time) (Occurrence, error) { return Prev(t, Archimedes, 1) }, gregorianDay(1969, time.July, 21), "01A -1"},
+	{"Next Friday", func(t time.Time) (Occurrence, error) { return NextWeekday(t, Friday) }, gregorianDay(1972, time.July, 19), "01A 4"},
+	{"Next Thursday", func(t time.Time) (Occurrence, error) { return NextWeekday(t, Thursday) }, gregorianDay(2000, time.February, 28), "28H 31"},
+	{"Prev Wednesday", func(t time.Time) (Occurrence, error) { return PrevWeekday(t, Wednesday) }, gregorianDay(2000, time.March, 1), "27H 31"},
+	{"Prev Thursday", func(t time.Time) (Occurrence, error) { return PrevWeekday(t, Thursday) }, gregorianDay(1969, time.July, 21), "28M -1"},
+}
+
+func TestSearch(t *testing.T) {
+	for _, tt := range searchTests {
+		o, err := tt.search(tt.from)
+		if err != nil {
+			t.Errorf("%s from %s returned %v", tt.name, tt.from.Format("2006-01-02"), err)
+			continue
+		}
+		if o.Date.ShortDate() != tt.output {
+			t.Errorf("%s from %s = %s; expected %s", tt.name, tt.from.Format("2006-01-02"), o.Date.ShortDate(), tt.output)
+		}
+		if FromTime(o.Time) != o.Date || o.Time.Hour() != 0 || o.Time.Location() != tt.from.Location() {
+			t.Errorf("%s from %s has Gregorian time %v, which does not match %s", tt.name, tt.from.Format("2006-01-02"), o.Time, tt.output)
+		}
+	}
+}
+
+func TestSearchInvalid(t *testing.T) {
+	from := gregorianDay(2000, time.January, 1)
+	if _, err := Next(from, Mendel, 29); err != ErrInvalidDate {
+		t.Errorf("Next 29 Mendel returned %v", err)
+	}
+	if _, err := Prev(from, SpecialDay, ArmstrongDay); err != ErrInvalidDate {
+		t.Errorf("Prev with SpecialDay returned %v", err)
+	}
+	if _, err := NextWeekday(from, SpecialWeekday); err != ErrInvalidDate {
+		t.Errorf("NextWeekday with SpecialWeekday returned %v", err)
+	}
+}
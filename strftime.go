@@ -0,0 +1,247 @@
+package tqtime
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//FormatTime returns the Tranquility date of t, using the Gregorian date of t in its own location, and the time of day of t, formatted according to layout. Like the format of strftime and date(1), layout is copied to the result except for directives starting with '%', which are replaced as follows:
+//
+//	%A   day of the week, such as "Thursday"
+//	%a   abbreviated day of the week, such as "Thu"
+//	%B   month, such as "Mendel"
+//	%b   abbreviated month, such as "Men" (also %h)
+//	%d   day of the month, 01 to 28
+//	%e   day of the month, space padded
+//	%m   month number, 01 to 13, or 00 on special days
+//	%u   day of the week number, 1 for Friday to 7 for Thursday, or 0 on special days
+//	%j   day of the year, 001 to 366, counting special days
+//	%Y   year, negative Before Tranquility, as in ShortDate
+//	%y   last two digits of the year, 00 to 99, without its sign
+//	%L   year with its era, such as "3 After Tranquility"
+//	%K   code of the day, such as "28M" or "ARM"
+//	%F   the compact format of ShortDate, such as "28M 3"
+//	%x   the descriptive format of LongDate
+//	%c   the descriptive format followed by %T
+//	%D   the same as %m/%d/%y
+//	%H   hour, 00 to 23
+//	%I   hour, 01 to 12
+//	%k   hour, space padded, 0 to 23
+//	%l   hour, space padded, 1 to 12
+//	%p   AM or PM
+//	%P   am or pm
+//	%M   minute, 00 to 59
+//	%S   second, 00 to 59
+//	%N   nanoseconds, 000000000 to 999999999
+//	%T   time of day, the same as %H:%M:%S
+//	%R   hour and minute, the same as %H:%M
+//	%r   12 hour time, the same as %I:%M:%S %p
+//	%Z   abbreviation of the time zone, such as "UTC"
+//	%z   offset from UTC, such as "+1000"
+//	%:z  offset from UTC with a colon, such as "+10:00"
+//	%s   seconds since 1 January 1970 UTC
+//	%n   a newline
+//	%t   a tab
+//	%%   a percent sign
+//
+//As in date(1), a flag between the '%' and a directive which writes a number changes its padding: '-' removes it, '_' pads with spaces and '0' pads with zeros, so %-d writes "3" where %d writes "03". Other directives ignore the flags.
+//
+//On special days %A writes the name of the day, such as "Armstrong Day", %a writes its code, such as "ARM", and %B, %b, %d and %e write nothing. Other characters following '%' are copied unchanged, along with the '%' and any flag. CheckLayout reports them.
+func FormatTime(t time.Time, layout string) string {
+	return string(AppendFormatTime(make([]byte, 0, len(layout)+32), t, layout))
+}
+
+//AppendFormatTime is like FormatTime but appends the result to b and returns the extended buffer.
+func AppendFormatTime(b []byte, t time.Time, layout string) []byte {
+	d := FromTime(t)
+	special := d.Month == SpecialDay
+	for i := 0; i < len(layout); i++ {
+		if layout[i] != '%' || i+1 == len(layout) {
+			b = append(b, layout[i])
+			continue
+		}
+		start := i
+		i++
+		var flag byte
+		if c := layout[i]; (c == '-' || c == '_' || c == '0') && i+1 < len(layout) {
+			flag = c
+			i++
+		}
+		switch c := layout[i]; c {
+		case 'A':
+			if special {
+				b = append(b, DayName(d.Day)...)
+			} else {
+				b = append(b, WeekdayName(d.Weekday())...)
+			}
+		case 'a':
+			if special {
+				b = append(b, DayCode(d.Day)...)
+			} else {
+				b = append(b, abbreviate(WeekdayName(d.Weekday()), true)...)
+			}
+		case 'B':
+			if !special {
+				b = append(b, monthName(d.Month)...)
+			}
+		case 'b', 'h':
+			if !special {
+				b = append(b, abbreviate(monthName(d.Month), true)...)
+			}
+		case 'd':
+			if !special {
+				b = appendNumber(b, int64(d.Day), 2, '0', flag)
+			}
+		case 'e':
+			if !special {
+				b = appendNumber(b, int64(d.Day), 2, ' ', flag)
+			}
+		case 'm':
+			b = appendNumber(b, int64(d.Month), 2, '0', flag)
+		case 'u':
+			b = strconv.AppendInt(b, int64(d.Weekday()), 10)
+		case 'j':
+			b = appendNumber(b, int64(d.yearDay()), 3, '0', flag)
+		case 'Y':
+			b = strconv.AppendInt(b, d.Year, 10)
+		case 'y':
+			b = appendNumber(b, twoDigitYear(d.Year), 2, '0', flag)
+		case 'L':
+			if d.Year == 0 {
+				b = append(b, DayName(MoonLandingDay)...)
+			} else {
+				b = LongDateOptions{}.appendYear(b, d.Year)
+			}
+		case 'K':
+			b = appendDayCode(b, d)
+		case 'F':
+			b = AppendShortDate(b, d)
+		case 'x':
+			b = AppendLongDate(b, d)
+		case 'c':
+			b = AppendLongDate(b, d)
+			b = t.AppendFormat(b, " 15:04:05")
+		case 'D':
+			b = appendPadded(b, int64(d.Month), 2, '0')
+			b = append(b, '/')
+			if !special {
+				b = appendPadded(b, int64(d.Day), 2, '0')
+			}
+			b = append(b, '/')
+			b = appendPadded(b, twoDigitYear(d.Year), 2, '0')
+		case 'H':
+			b = appendNumber(b, int64(t.Hour()), 2, '0', flag)
+		case 'I':
+			b = appendNumber(b, int64(hour12(t)), 2, '0', flag)
+		case 'k':
+			b = appendNumber(b, int64(t.Hour()), 2, ' ', flag)
+		case 'l':
+			b = appendNumber(b, int64(hour12(t)), 2, ' ', flag)
+		case 'p':
+			b = t.AppendFormat(b, "PM")
+		case 'P':
+			b = t.AppendFormat(b, "pm")
+		case 'M':
+			b = appendNumber(b, int64(t.Minute()), 2, '0', flag)
+		case 'S':
+			b = appendNumber(b, int64(t.Second()), 2, '0', flag)
+		case 'N':
+			b = appendNumber(b, int64(t.Nanosecond()), 9, '0', flag)
+		case 'T':
+			b = t.AppendFormat(b, "15:04:05")
+		case 'R':
+			b = t.AppendFormat(b, "15:04")
+		case 'r':
+			b = t.AppendFormat(b, "03:04:05 PM")
+		case 'Z':
+			b = t.AppendFormat(b, "MST")
+		case 'z':
+			b = t.AppendFormat(b, "-0700")
+		case ':':
+			if i+1 < len(layout) && layout[i+1] == 'z' {
+				b = t.AppendFormat(b, "-07:00")
+				i++
+			} else {
+				b = append(b, layout[start:i+1]...)
+			}
+		case 's':
+			b = strconv.AppendInt(b, t.Unix(), 10)
+		case 'n':
+			b = append(b, '\n')
+		case 't':
+			b = append(b, '\t')
+		case '%':
+			b = append(b, '%')
+		default:
+			b = append(b, layout[start:i+1]...)
+		}
+	}
+	return b
+}
+
+//formatDirectives are the characters which FormatTime understands after '%', apart from the ':' of %:z.
+const formatDirectives = "AaBbhdemujYyLKFxcDHIklpPMSNTRrZzsnt%"
+
+//CheckLayout returns an error naming the first directive of layout which FormatTime does not understand, and would copy unchanged. A '%' at the end of layout is copied as it is by date(1), and is not an error.
+func CheckLayout(layout string) error {
+	for i := 0; i < len(layout)-1; i++ {
+		if layout[i] != '%' {
+			continue
+		}
+		start := i
+		i++
+		if c := layout[i]; (c == '-' || c == '_' || c == '0') && i+1 < len(layout) {
+			i++
+		}
+		c := layout[i]
+		if c == ':' && i+1 < len(layout) && layout[i+1] == 'z' {
+			i++
+			continue
+		}
+		if strings.IndexByte(formatDirectives, c) < 0 {
+			return fmt.Errorf("tqtime: unknown directive %q in layout", layout[start:i+1])
+		}
+	}
+	return nil
+}
+
+//appendNumber appends n like appendPadded, unless flag, one of the padding flags of date(1), changes the padding: '-' removes it, '_' pads with spaces and '0' pads with zeros.
+func appendNumber(b []byte, n int64, width int, pad byte, flag byte) []byte {
+	switch flag {
+	case '-':
+		width = 0
+	case '_':
+		pad = ' '
+	case '0':
+		pad = '0'
+	}
+	return appendPadded(b, n, width, pad)
+}
+
+//twoDigitYear returns the last two digits of the year y, without its sign, for %y.
+func twoDigitYear(y int64) int64 {
+	if y < 0 {
+		return -(y % 100)
+	}
+	return y % 100
+}
+
+//hour12 returns the hour of t on a 12 hour clock, 1 to 12.
+func hour12(t time.Time) int {
+	if h := t.Hour() % 12; h != 0 {
+		return h
+	}
+	return 12
+}
+
+//appendPadded appends the non-negative number n in decimal, padded on the left with pad to at least width characters.
+func appendPadded(b []byte, n int64, width int, pad byte) []byte {
+	var digits [20]byte
+	s := strconv.AppendInt(digits[:0], n, 10)
+	for i := len(s); i < width; i++ {
+		b = append(b, pad)
+	}
+	return append(b, s...)
+}
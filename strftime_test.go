@@ -0,0 +1,83 @@
+package tqtime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatTime(t *testing.T) {
+	brisbane := time.FixedZone("AEST", 10*60*60)
+	var formatTests = []struct {
+		t      time.Time
+		layout string
+		output string
+	}{
+		{time.Date(1972, time.July, 19, 9, 5, 3, 42, time.UTC), "%A, %d %B, %L", "Thursday, 28 Mendel, 3 After Tranquility"},
+		{time.Date(1972, time.July, 19, 9, 5, 3, 42, time.UTC), "%a %e %b %Y", "Thu 28 Men 3"},
+		{time.Date(1969, time.July, 23, 9, 5, 3, 42, time.UTC), "%a %e %h %Y", "Sun  3 Arc 1"},
+		{time.Date(1972, time.July, 19, 9, 5, 3, 42, time.UTC), "%F|%K|%m|%u|%j", "28M 3|28M|13|7|365"},
+		{time.Date(1972, time.July, 19, 21, 5, 3, 42, time.UTC), "%H %I %p %M %S %N", "21 09 PM 05 03 000000042"},
+		{time.Date(1972, time.July, 19, 21, 5, 3, 0, time.UTC), "%T|%R|%Z|%z|%:z|%s", "21:05:03|21:05|UTC|+0000|+00:00|80427903"},
+		{time.Date(1972, time.July, 19, 21, 5, 3, 0, brisbane), "%Z %z %:z", "AEST +1000 +10:00"},
+		{time.Date(1972, time.July, 19, 21, 5, 3, 0, time.UTC), "%x", "Thursday, 28 Mendel, 3 After Tranquility"},
+		{time.Date(1972, time.July, 19, 21, 5, 3, 0, time.UTC), "%c", "Thursday, 28 Mendel, 3 After Tranquility 21:05:03"},
+		{time.Date(2000, time.February, 29, 0, 0, 0, 0, time.UTC), "%A|%a|%B|%b|%d|%e|%m|%u|%j|%F", "Aldrin Day|ALD|||||00|0|224|ALD 31"},
+		{time.Date(1970, time.July, 20, 0, 0, 0, 0, time.UTC), "%A, %L", "Armstrong Day, 1 After Tranquility"},
+		{time.Date(1969, time.July, 20, 0, 0, 0, 0, time.UTC), "%x|%L|%Y|%K", "Moon Landing Day|Moon Landing Day|0|MNL"},
+		{time.Date(1969, time.July, 19, 0, 0, 0, 0, time.UTC), "%L", "1 Before Tranquility"},
+		{time.Date(1972, time.July, 19, 0, 0, 0, 0, time.UTC), "100%% %n%t%q %:x %", "100% \n\t%q %:x %"},
+		{time.Date(1969, time.July, 23, 7, 5, 3, 0, time.UTC), "%-d|%_d|%0e|%-m|%_j|%-H|%_M|%-S", "3| 3|03|1|  3|7| 5|3"},
+		{time.Date(1972, time.July, 19, 21, 5, 3, 0, time.UTC), "%y|%D|%k|%l|%P|%r|%-l", "03|13/28/03|21| 9|pm|09:05:03 PM|9"},
+		{time.Date(1972, time.July, 19, 0, 5, 3, 0, time.UTC), "%k|%l|%I|%-k", " 0|12|12|0"},
+		{time.Date(2000, time.February, 29, 0, 0, 0, 0, time.UTC), "%D|%-d|%y", "00//31||31"},
+		{time.Date(1868, time.July, 19, 0, 0, 0, 0, time.UTC), "%y|%Y", "02|-102"},
+		{time.Date(1972, time.July, 19, 0, 0, 0, 0, time.UTC), "%-q %_A %0 %-", "%-q Thursday %0 %-"},
+	}
+	for _, tt := range formatTests {
+		actual := FormatTime(tt.t, tt.layout)
+		if actual != tt.output {
+			t.Errorf("FormatTime(%v, %q) = %q, expected %q", tt.t, tt.layout, actual, tt.output)
+		}
+	}
+}
+
+func TestAppendFormatTimeDoesNotAllocate(t *testing.T) {
+	ti := time.Date(1972, time.July, 19, 21, 5, 3, 0, time.UTC)
+	buf := make([]byte, 0, 128)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = AppendFormatTime(buf[:0], ti, "%A, %d %B, %L %T %:z")
+	})
+	if allocs != 0 {
+		t.Errorf("AppendFormatTime allocated %v times", allocs)
+	}
+}
+
+func TestFormatDirectives(t *testing.T) {
+	ti := time.Date(1972, time.July, 19, 21, 5, 3, 0, time.UTC)
+	for _, c := range formatDirectives {
+		if layout := "%" + string(c); FormatTime(ti, layout) == layout {
+			t.Errorf("FormatTime copies %q, which CheckLayout accepts", layout)
+		}
+	}
+}
+
+func TestCheckLayout(t *testing.T) {
+	var checkTests = []struct {
+		layout string
+		err    string
+	}{
+		{"%A, %-d %B %_H:%0M %:z %%", ""},
+		{"100%", ""},
+		{"%F %q", `tqtime: unknown directive "%q" in layout`},
+		{"%-q", `tqtime: unknown directive "%-q" in layout`},
+		{"%:x", `tqtime: unknown directive "%:" in layout`},
+		{"%-", `tqtime: unknown directive "%-" in layout`},
+		{"%G-W%V", `tqtime: unknown directive "%G" in layout`},
+	}
+	for _, tt := range checkTests {
+		err := CheckLayout(tt.layout)
+		if (err == nil && tt.err != "") || (err != nil && err.Error() != tt.err) {
+			t.Errorf("CheckLayout(%q) = %v; expected %q", tt.layout, err, tt.err)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package tqtime
+
+import (
+	"sync"
+	"time"
+)
+
+//Boundary is a set of Tranquility calendar units, used to select which changes of date a TqTicker reports.
+type Boundary int
+
+//The units of the Tranquility calendar whose changes can be reported. Weeks and months are those of WeekBounds and MonthBounds: Aldrin Day is part of the fourth week of Hippocrates, so the transitions into and out of it are only day boundaries. Armstrong Day is not part of any week or month, so the transitions into and out of it are week and month boundaries as well. The transition from Armstrong Day to 1 Archimedes is also a year boundary.
+const (
+	DayBoundary Boundary = 1 << iota
+	WeekBoundary
+	MonthBoundary
+	YearBoundary
+)
+
+//monthAndWeek returns the month of d and its week, counting from 1, as spanned by MonthBounds and WeekBounds. Aldrin Day is in the fourth week of Hippocrates. Armstrong Day and Moon Landing Day are in no month or week, and give SpecialDay and 0.
+func monthAndWeek(d TqDate) (TqMonth, int) {
+	switch {
+	case d.Day == AldrinDay:
+		return Hippocrates, 4
+	case d.Day < 0:
+		return SpecialDay, 0
+	}
+	return d.Month, (d.Day-1)/7 + 1
+}
+
+//changedBetween returns the units that differ between the consecutive dates prev and next.
+func changedBetween(prev, next TqDate) Boundary {
+	changed := DayBoundary
+	prevMonth, prevWeek := monthAndWeek(prev)
+	nextMonth, nextWeek := monthAndWeek(next)
+	if prevMonth != nextMonth || prev.Year != next.Year {
+		changed |= MonthBoundary
+	}
+	if changed&MonthBoundary != 0 || prevWeek != nextWeek {
+		changed |= WeekBoundary
+	}
+	if prev.Year != next.Year {
+		changed |= YearBoundary
+	}
+	return changed
+}
+
+//NextBoundary returns the first midnight after t, in the location of t, at which one of the units in watch changes. It also returns the Tranquility date starting at that midnight, and every unit which changes there.
+func NextBoundary(t time.Time, watch Boundary) (time.Time, TqDate, Boundary, error) {
+	prev := FromTime(t)
+	for {
+		next, err := prev.AddDays(1)
+		if err != nil {
+			return time.Time{}, TqDate{}, 0, err
+		}
+		if changed := changedBetween(prev, next); changed&watch != 0 {
+			midnight, err := next.Time(t.Location())
+			return midnight, next, changed, err
+		}
+		prev = next
+	}
+}
+
+//Tick is delivered by a TqTicker when the Tranquility date changes.
+type Tick struct {
+	//Time is the midnight at which the date changed.
+	Time time.Time
+	//Date is the new Tranquility date.
+	Date TqDate
+	//Changed holds every unit which changed, including those that were not requested.
+	Changed Boundary
+}
+
+//TqTicker delivers a Tick on its channel whenever one of the requested Tranquility calendar units changes, similar to time.Ticker.
+type TqTicker struct {
+	//C is the channel on which ticks are delivered.
+	C        <-chan Tick
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+//NewTicker returns a TqTicker which reports the changes of the units in watch, reading the time from clock and finding day boundaries in loc. Unlike time.Ticker, ticks are not dropped for slow receivers: each boundary is delivered in turn. The ticker wakes at least every 15 minutes to read the clock, so a boundary passed while the computer was suspended, or the clock was changed, is delivered soon after.
+func NewTicker(clock Clock, loc *time.Location, watch Boundary) *TqTicker {
+	c := make(chan Tick)
+	t := &TqTicker{C: c, stop: make(chan struct{})}
+	go t.run(clock, loc, watch, c)
+	return t
+}
+
+func (t *TqTicker) run(clock Clock, loc *time.Location, watch Boundary, c chan<- Tick) {
+	from := clock.Now().In(loc)
+	for {
+		next, date, changed, err := NextBoundary(from, watch)
+		if err != nil {
+			return
+		}
+		//A wait may end before the boundary by the wall clock, if the clock was set back, so wait again until it has really passed.
+		for now := clock.Now(); now.Before(next); now = clock.Now() {
+			wait := next.Sub(now)
+			if wait > maxWait {
+				wait = maxWait
+			}
+			select {
+			case <-clock.After(wait):
+			case <-t.stop:
+				return
+			}
+		}
+		select {
+		case c <- Tick{Time: next, Date: date, Changed: changed}:
+		case <-t.stop:
+			return
+		}
+		from = next
+	}
+}
+
+//Stop turns off the ticker, after which no more boundaries are waited for. Like time.Ticker.Stop, it does not close the channel. Calling Stop more than once has no further effect.
+func (t *TqTicker) Stop() {
+	t.stopOnce.Do(func() { close(t.stop) })
+}
@@ -0,0 +1,119 @@
+package tqtime
+
+import (
+	"testing"
+	"time"
+)
+
+var boundaryTests = []struct {
+	from    time.Time
+	watch   Boundary
+	output  string
+	changed Boundary
+}{
+	//Aldrin Day is part of Hippocrates and its fourth week, so entering and leaving it only changes the day.
+	{time.Date(2000, time.February, 28, 13, 0, 0, 0, time.UTC), DayBoundary, "ALD 31", DayBoundary},
+	{time.Date(2000, time.February, 10, 13, 0, 0, 0, time.UTC), MonthBoundary, "01I 31", DayBoundary | WeekBoundary | MonthBoundary},
+	{time.Date(2000, time.February, 29, 13, 0, 0, 0, time.UTC), DayBoundary, "28H 31", DayBoundary},
+	{time.Date(2000, time.February, 24, 13, 0, 0, 0, time.UTC), WeekBoundary, "01I 31", DayBoundary | WeekBoundary | MonthBoundary},
+	{time.Date(2000, time.February, 20, 13, 0, 0, 0, time.UTC), WeekBoundary, "22H 31", DayBoundary | WeekBoundary},
+	{time.Date(2000, time.February, 10, 13, 0, 0, 0, time.UTC), YearBoundary, "01A 32", DayBoundary | WeekBoundary | MonthBoundary | YearBoundary},
+	{time.Date(2000, time.July, 19, 0, 0, 0, 0, time.UTC), WeekBoundary, "ARM 31", DayBoundary | WeekBoundary | MonthBoundary},
+	{time.Date(1969, time.July, 1, 0, 0, 0, 0, time.UTC), YearBoundary, "MNL 0", DayBoundary | WeekBoundary | MonthBoundary | YearBoundary},
+	{time.Date(2000, time.July, 21, 0, 0, 0, 0, time.UTC), WeekBoundary, "08A 32", DayBoundary | WeekBoundary},
+	{time.Date(2000, time.July, 21, 0, 0, 0, 0, time.UTC), DayBoundary, "02A 32", DayBoundary},
+}
+
+func TestNextBoundary(t *testing.T) {
+	for _, tt := range boundaryTests {
+		next, d, changed, err := NextBoundary(tt.from, tt.watch)
+		if err != nil {
+			t.Errorf("NextBoundary(%v) returned %v", tt.from, err)
+			continue
+		}
+		if d.ShortDate() != tt.output || changed != tt.changed {
+			t.Errorf("NextBoundary(%v, %d) = %s, %d; expected %s, %d", tt.from, tt.watch, d.ShortDate(), changed, tt.output, tt.changed)
+		}
+		if FromTime(next) != d || !next.Equal(time.Date(next.Year(), next.Month(), next.Day(), 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("NextBoundary(%v) gave %v, which is not midnight on %s", tt.from, next, d.ShortDate())
+		}
+	}
+}
+
+func TestTicker(t *testing.T) {
+	loc := time.FixedZone("UTC-8", -8*60*60)
+	clock := NewFakeClock(time.Date(2000, time.February, 27, 12, 0, 0, 0, loc))
+	ticker := NewTicker(clock, loc, MonthBoundary)
+	defer ticker.Stop()
+
+	expected := []struct {
+		output string
+		time   time.Time
+	}{
+		{"01I 31", time.Date(2000, time.March, 2, 0, 0, 0, 0, loc)},
+		{"01J 31", time.Date(2000, time.March, 30, 0, 0, 0, 0, loc)},
+	}
+	clock.BlockUntilWaiting(1)
+	clock.Advance(32 * 24 * time.Hour)
+	for _, e := range expected {
+		select {
+		case tick := <-ticker.C:
+			if tick.Date.ShortDate() != e.output || !tick.Time.Equal(e.time) || tick.Changed&MonthBoundary == 0 {
+				t.Errorf("Ticker delivered %s at %v; expected %s at %v", tick.Date.ShortDate(), tick.Time, e.output, e.time)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Ticker did not deliver %s", e.output)
+		}
+	}
+	clock.BlockUntilWaiting(1)
+	select {
+	case tick := <-ticker.C:
+		t.Errorf("Ticker delivered %s early", tick.Date.ShortDate())
+	default:
+	}
+}
+
+//waitingUntil returns the time at which the After call that clock is waiting on ends.
+func waitingUntil(clock *FakeClock) time.Time {
+	clock.BlockUntilWaiting(1)
+	clock.mu.Lock()
+	defer clock.mu.Unlock()
+	return clock.waiters[0].deadline
+}
+
+func TestTickerWakes(t *testing.T) {
+	start := time.Date(2000, time.February, 27, 12, 0, 0, 0, time.UTC)
+	midnight := time.Date(2000, time.February, 28, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	ticker := NewTicker(clock, time.UTC, DayBoundary)
+	defer ticker.Stop()
+
+	if deadline := waitingUntil(clock); !deadline.Equal(start.Add(maxWait)) {
+		t.Fatalf("Ticker waited until %v; expected %v", deadline, start.Add(maxWait))
+	}
+	//A wait ending long before midnight, or just before it, must not deliver the new day.
+	for _, now := range []time.Time{start.Add(maxWait), midnight.Add(-time.Minute)} {
+		clock.Set(now)
+		expected := now.Add(maxWait)
+		if expected.After(midnight) {
+			expected = midnight
+		}
+		if deadline := waitingUntil(clock); !deadline.Equal(expected) {
+			t.Fatalf("Ticker woken at %v waited until %v; expected %v", now, deadline, expected)
+		}
+		select {
+		case tick := <-ticker.C:
+			t.Fatalf("Ticker delivered %s at %v", tick.Date.ShortDate(), now)
+		default:
+		}
+	}
+	clock.Set(midnight)
+	select {
+	case tick := <-ticker.C:
+		if tick.Date.ShortDate() != "27H 31" || !tick.Time.Equal(midnight) {
+			t.Errorf("Ticker delivered %s at %v; expected 27H 31 at %v", tick.Date.ShortDate(), tick.Time, midnight)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Ticker did not deliver 27H 31")
+	}
+}
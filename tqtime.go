@@ -4,7 +4,6 @@ package tqtime
 //Note to modders: For nonexported symbols in this package, the prefix 'g' is Gregorian, and the prefix 'tq' is Tranquility.
 
 import (
-	"fmt"
 	"strconv"
 	"time"
 )
@@ -57,15 +56,17 @@ const MoonLandingDay int = -3
 const commonYearLen int = 365
 const gCommonYearArmstrongDay int = 201
 const tqMonthLen int = 28
-const gMoonLandingYear int = 1969
+const gMoonLandingYear int64 = 1969
+const gCycleYears int64 = 400
+const gCycleDays int64 = 146097
 
 //gLeapYear returns true if gy is a Gregorian leap year.
-func gLeapYear(gy int) bool {
+func gLeapYear(gy int64) bool {
 	return (gy%400 == 0) || (gy%4 == 0 && gy%100 != 0)
 }
 
 //gYearLen returns the length of Gregorian year gy, taking into account leap years.
-func gYearLen(gy int) int {
+func gYearLen(gy int64) int {
 	if gLeapYear(gy) {
 		return commonYearLen + 1
 	}
@@ -73,27 +74,50 @@ func gYearLen(gy int) int {
 }
 
 //gArmstrongDay returns the day of the Gregorian year gy that corresponds to Armstrong Day (July 20), taking into account leap years.
-func gArmstrongDay(gy int) int {
+func gArmstrongDay(gy int64) int {
 	if gLeapYear(gy) {
 		return gCommonYearArmstrongDay + 1
 	}
 	return gCommonYearArmstrongDay
 }
 
-//gNormalize converts an arbitrary Gregorian year & day of year into a normalized Gregorian year & day of year. The resulting day of year is guaranteed to be in range [0,366] if the resulting year is a leap year, and guaranteed to be in range [0,365] if the resulting year is a common year. For instance gy1 = 2006, gyd1 = 0 normalizes to gy2 = 2005, gyd2 = 365, and gy1 = 2006, gyd1 = -1 normalizes to gy2 = 2005, gyd2 = 364.
-func gNormalize(gy1, gyd1 int) (gy2, gyd2 int) {
-	gy2, gyd2 = gy1, gyd1
-	for gyd2 < 1 {
-		gyd2 += (gYearLen(gy2) - 1)
-		gy2--
-	}
-	for gyd2 > gYearLen(gy2) {
-		gyd2 -= gYearLen(gy2)
-		gy2++
+//floorDiv returns the quotient of a / b rounded towards negative infinity, and the corresponding remainder in range [0,b-1]. b must be positive.
+func floorDiv(a, b int64) (q, r int64) {
+	q, r = a/b, a%b
+	if r < 0 {
+		q--
+		r += b
 	}
 	return
 }
 
+//gDaysBeforeYear returns the number of days from 1 January of Gregorian year 0 to 1 January of Gregorian year gy, using the proleptic Gregorian calendar. The result is negative for years before 0. Whole 400 year cycles are counted in one step, so the cost does not depend on gy.
+func gDaysBeforeYear(gy int64) int64 {
+	cycles, y := floorDiv(gy, gCycleYears)
+	return cycles*gCycleDays + y*int64(commonYearLen) + (y+3)/4 - (y+99)/100 + (y+399)/400
+}
+
+//gFromDayNumber is the inverse of gDaysBeforeYear: it converts a count of days since 1 January of Gregorian year 0 into a normalized Gregorian year and day of year.
+func gFromDayNumber(n int64) (gy int64, gyd int) {
+	cycles, d := floorDiv(n, gCycleDays)
+	y := d * gCycleYears / gCycleDays
+	for gDaysBeforeYear(y) > d {
+		y--
+	}
+	for gDaysBeforeYear(y+1) <= d {
+		y++
+	}
+	return cycles*gCycleYears + y, int(d-gDaysBeforeYear(y)) + 1
+}
+
+//gNormalize converts an arbitrary Gregorian year & day of year into a normalized Gregorian year & day of year, matching the behaviour of time.Date(gy1, time.January, gyd1, ...). The resulting day of year is guaranteed to be in range [1,366] if the resulting year is a leap year, and guaranteed to be in range [1,365] if the resulting year is a common year. For instance gy1 = 2006, gyd1 = 0 normalizes to gy2 = 2005, gyd2 = 365, and gy1 = 2001, gyd1 = -1 normalizes to gy2 = 2000, gyd2 = 365. Normalization takes constant time regardless of how far gyd1 is from the year gy1. The caller is responsible for keeping gy1 and gyd1 within the limits checked by FromGregorian.
+func gNormalize(gy1, gyd1 int64) (gy2 int64, gyd2 int) {
+	if gyd1 >= 1 && gyd1 <= int64(gYearLen(gy1)) {
+		return gy1, int(gyd1)
+	}
+	return gFromDayNumber(gDaysBeforeYear(gy1) + gyd1 - 1)
+}
+
 //clockModulo returns the modulo as a number in range [1,b] rather than a number in range [0,b-1]. If a % b is zero, b is returned. Otherwise a % b is returned. This is important because calendars tend to have cycles but rarely count from 0.
 func clockModulo(a, b int) int {
 	mod := a % b
@@ -104,9 +128,8 @@ func clockModulo(a, b int) int {
 }
 
 //tqLeapAdjustedYearDay converts a Tranquility day of year and a Gregorian year into a value which is easier to calculate with. If the Gregorian year and Tranquility day of year corresponds to a special day, then that day's constant is returned. Otherwise, the corresponding day of common Tranquility year is returned. For instance if tqyd = 300 and gy = 2000, that represents a day after Aldrin Day on a leap year: the corresponding day of common Tranqility year is 299.
-func tqLeapAdjustedYearDay(tqyd, gy int) int {
+func tqLeapAdjustedYearDay(tqyd int, gy int64) int {
 	if gLeapYear(gy) {
-		const tqydAldrin int = tqMonthLen * int(Hippocrates)
 		if tqyd == tqydAldrin {
 			return AldrinDay
 		} else if tqyd > tqydAldrin {
@@ -122,18 +145,57 @@ func tqLeapAdjustedYearDay(tqyd, gy int) int {
 	return tqyd
 }
 
-//YearDay returns the day of the Tranquility year of the given Gregorian year and day of year.
-func YearDay(gYear, gDayOfYear int) int {
-	gYear, gDayOfYear = gNormalize(gYear, gDayOfYear)
+//tqydAldrin is the day of a Tranquility leap year which is Aldrin Day.
+const tqydAldrin int = tqMonthLen * int(Hippocrates)
+
+//tqYearDay returns the day of the Tranquility year of a normalized Gregorian year and day of year.
+func tqYearDay(gy int64, gyd int) int {
 	shift := commonYearLen - gCommonYearArmstrongDay
-	return clockModulo((gDayOfYear + shift), gYearLen(gYear))
+	return clockModulo((gyd + shift), gYearLen(gy))
 }
 
-//IsBeforeTranquility returns true if and only if the given Gregorian time is before 20:18:01.2 on Moon Landing Day. This is the exact moment that Neil Armstrong said the word "Tranquility" in the phrase "Houston, Tranquility Base here. The Eagle has landed."
+//tqYear returns the Tranquility year of a normalized Gregorian year and day of year.
+func tqYear(gy int64, gyd int) int64 {
+	if gy == gMoonLandingYear && gyd == gCommonYearArmstrongDay {
+		return 0
+	}
+	diff := gy - gMoonLandingYear
+	if gyd > gArmstrongDay(gy) {
+		diff++
+	}
+	if diff < 1 { //For 1 AT, depends on previous if statement.
+		diff--
+	}
+	return diff
+}
+
+//tqDateOf converts a normalized Gregorian year and day of year into a Tranquility date.
+func tqDateOf(gy int64, gyd int) TqDate {
+	d := TqDate{Year: tqYear(gy, gyd)}
+	tqyd := tqLeapAdjustedYearDay(tqYearDay(gy, gyd), gy)
+	if tqyd < 0 {
+		d.Day = tqyd
+		return d
+	}
+	d.Month = TqMonth(((tqyd - 1) / tqMonthLen) + 1)
+	d.Day = clockModulo(tqyd, tqMonthLen)
+	return d
+}
+
+//gDate converts the Gregorian year and day of year accepted by the int based functions of this package into a Tranquility date. Like gNormalize, it does not check its arguments, so each int based function documents the limits of FromGregorian.
+func gDate(gYear, gDayOfYear int) TqDate {
+	return tqDateOf(gNormalize(int64(gYear), int64(gDayOfYear)))
+}
+
+//YearDay returns the day of the Tranquility year of the given Gregorian year and day of year. The arguments must be within the limits checked by FromGregorian: beyond them the arithmetic overflows, and the result is meaningless.
+func YearDay(gYear, gDayOfYear int) int {
+	return tqYearDay(gNormalize(int64(gYear), int64(gDayOfYear)))
+}
+
+//IsBeforeTranquility returns true if and only if the given Gregorian time is before 20:18:01.2 on Moon Landing Day. This is the exact moment that Neil Armstrong said the word "Tranquility" in the phrase "Houston, Tranquility Base here. The Eagle has landed." The year and day of year must be within the limits checked by FromGregorian: beyond them the arithmetic overflows, and the result is meaningless.
 func IsBeforeTranquility(gYear, gDayOfYear, hour, minute, sec, millisec int) bool {
-	gYear, gDayOfYear = gNormalize(gYear, gDayOfYear)
-	tqYear := Year(gYear, gDayOfYear)
-	const mlYear int = 0
+	tqYear := gDate(gYear, gDayOfYear).Year
+	const mlYear int64 = 0
 	const mlHour int = 20
 	const mlMinute int = 18
 	const mlSec int = 1
@@ -162,54 +224,39 @@ func IsBeforeTranquility(gYear, gDayOfYear, hour, minute, sec, millisec int) boo
 	}
 }
 
-//Year returns the Tranquility year of the given Gregorian year and day of year. This is defined as the years since the first moon landing. Years before Moon Landing Day are represented as negative, Moon Landing Day itself is represented with 0, and years after Moon Landing Day are represented as positive.
+//Year returns the Tranquility year of the given Gregorian year and day of year. This is defined as the years since the first moon landing. Years before Moon Landing Day are represented as negative, Moon Landing Day itself is represented with 0, and years after Moon Landing Day are represented as positive. The arguments must be within the limits checked by FromGregorian: beyond them the arithmetic overflows, and the result is meaningless.
 func Year(gYear, gDayOfYear int) int {
-	gYear, gDayOfYear = gNormalize(gYear, gDayOfYear)
-	if gYear == gMoonLandingYear && gDayOfYear == gCommonYearArmstrongDay {
-		return 0
-	}
-	diff := gYear - gMoonLandingYear
-	if gDayOfYear > gArmstrongDay(gYear) {
-		diff++
-	}
-	if diff < 1 { //For 1 AT, depends on previous if statement.
-		diff--
-	}
-	return diff
+	return int(gDate(gYear, gDayOfYear).Year)
 }
 
-//Month returns the Tranquility month of the given Gregorian year and day of year. If the provided date does not fall on a month, SpecialDay is returned.
+//Month returns the Tranquility month of the given Gregorian year and day of year. If the provided date does not fall on a month, SpecialDay is returned. The arguments must be within the limits checked by FromGregorian: beyond them the arithmetic overflows, and the result is meaningless.
 func Month(gYear, gDayOfYear int) TqMonth {
-	gYear, gDayOfYear = gNormalize(gYear, gDayOfYear)
-	tqyd := tqLeapAdjustedYearDay(YearDay(gYear, gDayOfYear), gYear)
-	if tqyd < 0 {
-		return SpecialDay
-	}
-	return TqMonth(((tqyd - 1) / tqMonthLen) + 1)
+	return gDate(gYear, gDayOfYear).Month
 }
 
-//Day returns the day of the Tranquility month of the given Gregorian year and day of year. If the provided date does not fall on a month, a special negative value is returned: one of MoonLandingDay, ArmstrongDay or AldrinDay.
+//Day returns the day of the Tranquility month of the given Gregorian year and day of year. If the provided date does not fall on a month, a special negative value is returned: one of MoonLandingDay, ArmstrongDay or AldrinDay. The arguments must be within the limits checked by FromGregorian: beyond them the arithmetic overflows, and the result is meaningless.
 func Day(gYear, gDayOfYear int) int {
-	gYear, gDayOfYear = gNormalize(gYear, gDayOfYear)
-	tqyd := tqLeapAdjustedYearDay(YearDay(gYear, gDayOfYear), gYear)
-	if tqyd < 0 {
-		return tqyd
-	}
-	return clockModulo(tqyd, tqMonthLen)
+	return gDate(gYear, gDayOfYear).Day
 }
 
-//Weekday returns the Tranquility day of the week of the provided Gregorian year and day of year. If the provided date does not fall on a week, the value SpecialWeekday is returned.
+//Weekday returns the Tranquility day of the week of the provided Gregorian year and day of year. If the provided date does not fall on a week, the value SpecialWeekday is returned. The arguments must be within the limits checked by FromGregorian: beyond them the arithmetic overflows, and the result is meaningless.
 func Weekday(gYear, gDayOfYear int) TqWeekday {
-	gYear, gDayOfYear = gNormalize(gYear, gDayOfYear)
-	tqd := Day(gYear, gDayOfYear)
-	if tqd < 0 {
-		return SpecialWeekday
-	}
-	return TqWeekday(clockModulo(tqd, 7))
+	return gDate(gYear, gDayOfYear).Weekday()
 }
 
-//String returns the English name of the given Tranquility month. If m is not a valid month, a blank string is returned.
+//String returns the English name of the given Tranquility month. SpecialDay is "SpecialDay", and other values which are not months are written like "TqMonth(14)".
 func (tqm TqMonth) String() string {
+	switch {
+	case tqm == SpecialDay:
+		return "SpecialDay"
+	case tqm < Archimedes || tqm > Mendel:
+		return "TqMonth(" + strconv.Itoa(int(tqm)) + ")"
+	}
+	return monthName(tqm)
+}
+
+//monthName returns the English name of the given Tranquility month, or a blank string if tqm is not a month.
+func monthName(tqm TqMonth) string {
 	if tqm < Archimedes || tqm > Mendel {
 		return ""
 	}
@@ -233,7 +280,7 @@ func (tqm TqMonth) String() string {
 
 //MonthLetter returns the first letter of the name of the given Tranquility month. If m is not a valid month, a blank string is returned.
 func MonthLetter(tqm TqMonth) string {
-	name := tqm.String()
+	name := monthName(tqm)
 	if len(name) > 0 {
 		return name[:1]
 	}
@@ -276,38 +323,12 @@ func WeekdayName(tqwd TqWeekday) string {
 	return time.Weekday((int(tqwd) + 4) % 7).String()
 }
 
-//ShortDate takes a Gregorian year and day of year, and returns the string representation of the Tranquility Date in a compact format. On special days, the result is "DDD %y", where DDD is a 3 character day code. On other days, the result is "DDM %y" where DD is the zero-padded day of the month, M is the first letter of the month name. In both cases, %y is a variable-length integer representing the year. %y is preceded by '-' on years Before Tranquility.
+//ShortDate takes a Gregorian year and day of year, and returns the string representation of the Tranquility Date in a compact format. On special days, the result is "DDD %y", where DDD is a 3 character day code. On other days, the result is "DDM %y" where DD is the zero-padded day of the month, M is the first letter of the month name. In both cases, %y is a variable-length integer representing the year. %y is preceded by '-' on years Before Tranquility. The arguments must be within the limits checked by FromGregorian: beyond them the arithmetic overflows, and the result is meaningless.
 func ShortDate(gYear, gDayOfYear int) string {
-	gYear, gDayOfYear = gNormalize(gYear, gDayOfYear)
-	tqmd := Day(gYear, gDayOfYear)
-	tqy := Year(gYear, gDayOfYear)
-	if tqmd < 0 {
-		return fmt.Sprintf("%s %d", DayCode(tqmd), tqy)
-	}
-	tqml := MonthLetter(Month(gYear, gDayOfYear))
-	return fmt.Sprintf("%02d%s %d", tqmd, tqml, tqy)
+	return gDate(gYear, gDayOfYear).ShortDate()
 }
 
-//LongDate takes a Gregorian year and day of year, and returns the string representation of the Tranquility Date in a descriptive format.
+//LongDate takes a Gregorian year and day of year, and returns the string representation of the Tranquility Date in a descriptive format. The arguments must be within the limits checked by FromGregorian: beyond them the arithmetic overflows, and the result is meaningless.
 func LongDate(gYear, gDayOfYear int) string {
-	gYear, gDayOfYear = gNormalize(gYear, gDayOfYear)
-	tqmd := Day(gYear, gDayOfYear)
-	if tqmd == MoonLandingDay {
-		return DayName(tqmd)
-	}
-
-	tqy := Year(gYear, gDayOfYear)
-	var suffix string
-	if tqy < 0 {
-		suffix = "Before Tranquility"
-		tqy = -1 * tqy
-	} else {
-		suffix = "After Tranquility"
-	}
-	if tqmd < 0 {
-		return fmt.Sprintf("%s, %d %s", DayName(tqmd), tqy, suffix)
-	}
-	tqwd := WeekdayName(Weekday(gYear, gDayOfYear))
-	tqmn := Month(gYear, gDayOfYear)
-	return fmt.Sprintf("%s, %s %v, %d %s", tqwd, DayName(tqmd), tqmn, tqy, suffix)
+	return gDate(gYear, gDayOfYear).LongDate()
 }
@@ -198,8 +198,11 @@ func TestDayNameOrdinary(t *testing.T) {
 }
 
 func TestMonthNameInvalid(t *testing.T) {
-	if TqMonth(-99).String() != "" {
-		t.Error("TqMonth.String did not return blank with invalid value.")
+	if s := TqMonth(-99).String(); s != "TqMonth(-99)" {
+		t.Errorf("TqMonth(-99).String() = %q; expected \"TqMonth(-99)\"", s)
+	}
+	if s := SpecialDay.String(); s != "SpecialDay" {
+		t.Errorf("SpecialDay.String() = %q; expected \"SpecialDay\"", s)
 	}
 }
 
@@ -219,6 +222,10 @@ var NormalizeTests = []struct {
 	{2000, 1, "25F 31"},
 	{2000, 0, "24F 31"},
 	{2000, -1, "23F 31"},
+	{2001, 0, "24F 32"},
+	{2001, -1, "23F 32"},
+	{2001, -365, "25F 31"},
+	{1970, -164, "MNL 0"},
 }
 
 func TestNormalizedDates(t *testing.T) {
@@ -226,7 +233,70 @@ func TestNormalizedDates(t *testing.T) {
 		actual := ShortDate(tt.gYear, tt.gDay)
 		expected := tt.output
 		if actual != expected {
-			t.Error("Bad normalize (%d,%d), expected '%s', actual '%s", tt.gYear, tt.gDay, expected, actual)
+			t.Errorf("Bad normalize (%d,%d), expected '%s', actual '%s'", tt.gYear, tt.gDay, expected, actual)
+		}
+	}
+}
+
+func checkNormalize(t *testing.T, gYear, gDay int) {
+	gt := time.Date(gYear, time.January, gDay, 12, 0, 0, 0, time.UTC)
+	y, yd := gNormalize(int64(gYear), int64(gDay))
+	if y != int64(gt.Year()) || yd != gt.YearDay() {
+		t.Errorf("gNormalize(%d,%d) = (%d,%d); time.Date gives (%d,%d)", gYear, gDay, y, yd, gt.Year(), gt.YearDay())
+	}
+}
+
+func TestNormalizeMatchesTimeDate(t *testing.T) {
+	for gYear := 1890; gYear <= 2110; gYear++ {
+		for gDay := -800; gDay <= 1200; gDay++ {
+			checkNormalize(t, gYear, gDay)
+		}
+	}
+	for _, gDay := range []int{-10000000, -1000000, -146097, 146097, 1000000, 10000000} {
+		for _, gYear := range []int{-400, 0, 1, 1969, 2000, 2001} {
+			checkNormalize(t, gYear, gDay)
 		}
 	}
 }
+
+func TestIntLimits(t *testing.T) {
+	//The int based functions must not overflow anywhere within the limits of FromGregorian. Whole 400 year cycles of days keep the expected years exact.
+	limit := gInputLimit
+	cycles := limit * int64(commonYearLen+1) / gCycleDays
+	var limitTests = []struct {
+		gYear, gDayOfYear int64
+		year              int64
+	}{
+		{limit, 1, limit - gMoonLandingYear},
+		{-limit, 1, -limit - gMoonLandingYear - 1},
+		{gMoonLandingYear, cycles*gCycleDays + 1, cycles * gCycleYears},
+		{gMoonLandingYear, -cycles*gCycleDays + 1, -cycles*gCycleYears - 1},
+	}
+	for _, tt := range limitTests {
+		if actual := Year(int(tt.gYear), int(tt.gDayOfYear)); int64(actual) != tt.year {
+			t.Errorf("Year(%d, %d) = %d; expected %d", tt.gYear, tt.gDayOfYear, actual, tt.year)
+		}
+	}
+}
+
+func TestDaysBeforeYear(t *testing.T) {
+	epoch := time.Date(0, time.January, 1, 0, 0, 0, 0, time.UTC)
+	for gYear := int64(-1200); gYear <= 2400; gYear++ {
+		gt := time.Date(int(gYear), time.January, 1, 0, 0, 0, 0, time.UTC)
+		expected := (gt.Unix() - epoch.Unix()) / 86400
+		if actual := gDaysBeforeYear(gYear); actual != expected {
+			t.Errorf("gDaysBeforeYear(%d) = %d; expected %d", gYear, actual, expected)
+		}
+	}
+}
+
+func benchmarkShortDate(b *testing.B, gYear, gDayOfYear int) {
+	for i := 0; i < b.N; i++ {
+		ShortDate(gYear, gDayOfYear)
+	}
+}
+
+func BenchmarkShortDateNear(b *testing.B)      { benchmarkShortDate(b, 2000, 100) }
+func BenchmarkShortDateOffset(b *testing.B)    { benchmarkShortDate(b, 2000, 3000000) }
+func BenchmarkShortDateFarFuture(b *testing.B) { benchmarkShortDate(b, 1969, 500000000) }
+func BenchmarkShortDateFarPast(b *testing.B)   { benchmarkShortDate(b, 1969, -500000000) }
@@ -0,0 +1,76 @@
+package tqtime
+
+//TqYear represents a Tranquility year. Like the Year function, years Before Tranquility are negative, years After Tranquility are positive, and year 0 stands for Moon Landing Day, which is not part of any other year.
+type TqYear int64
+
+//IsLeap returns true if y is a leap year, which is a year containing Aldrin Day. Year 0 is never a leap year.
+func (y TqYear) IsLeap() bool {
+	return tqLeapYear(int64(y))
+}
+
+//HasAldrinDay returns true if y contains Aldrin Day. It is equivalent to IsLeap.
+func (y TqYear) HasAldrinDay() bool {
+	return y.IsLeap()
+}
+
+//HasArmstrongDay returns true if y ends on Armstrong Day. This is true of every year except 1 Before Tranquility, which is followed directly by Moon Landing Day, and year 0.
+func (y TqYear) HasArmstrongDay() bool {
+	return y != 0 && y != -1
+}
+
+//Len returns the number of days in y, counting Aldrin Day and Armstrong Day. Year 0 has a single day, Moon Landing Day.
+func (y TqYear) Len() int {
+	if y == 0 {
+		return 1
+	}
+	n := int(Mendel) * tqMonthLen
+	if y.HasAldrinDay() {
+		n++
+	}
+	if y.HasArmstrongDay() {
+		n++
+	}
+	return n
+}
+
+//First returns the first day of y: 1 Archimedes, or Moon Landing Day for year 0.
+func (y TqYear) First() TqDate {
+	if y == 0 {
+		return TqDate{Month: SpecialDay, Day: MoonLandingDay}
+	}
+	return TqDate{Year: int64(y), Month: Archimedes, Day: 1}
+}
+
+//Last returns the last day of y: Armstrong Day, 28 Mendel for 1 Before Tranquility, or Moon Landing Day for year 0.
+func (y TqYear) Last() TqDate {
+	switch {
+	case y == 0:
+		return TqDate{Month: SpecialDay, Day: MoonLandingDay}
+	case !y.HasArmstrongDay():
+		return TqDate{Year: int64(y), Month: Mendel, Day: tqMonthLen}
+	default:
+		return TqDate{Year: int64(y), Month: SpecialDay, Day: ArmstrongDay}
+	}
+}
+
+//GregorianStart returns the Gregorian year and day of year of the first day of y. ErrOutOfRange is returned if y is outside the limits MinYear and MaxYear.
+func (y TqYear) GregorianStart() (gYear int64, gDayOfYear int, err error) {
+	return y.First().Gregorian()
+}
+
+//GregorianEnd returns the Gregorian year and day of year of the last day of y, inclusive. ErrOutOfRange is returned if y is outside the limits MinYear and MaxYear.
+func (y TqYear) GregorianEnd() (gYear int64, gDayOfYear int, err error) {
+	return y.Last().Gregorian()
+}
+
+//Months returns the months of y in order. Every year has the same 13 months, except year 0 which has none.
+func (y TqYear) Months() []TqMonth {
+	if y == 0 {
+		return nil
+	}
+	months := make([]TqMonth, 0, Mendel)
+	for m := Archimedes; m <= Mendel; m++ {
+		months = append(months, m)
+	}
+	return months
+}
@@ -0,0 +1,84 @@
+package tqtime
+
+import "testing"
+
+var yearTests = []struct {
+	year      TqYear
+	leap      bool
+	armstrong bool
+	length    int
+}{
+	{40, false, true, 365},
+	{31, true, true, 366},
+	{3, true, true, 366},
+	{1, false, true, 365},
+	{0, false, false, 1},
+	{-1, false, false, 364},
+	{-2, true, true, 366},
+	{-70, false, true, 365},
+	{31031, false, true, 365},
+	{30031, true, true, 366},
+}
+
+func TestYearMetadata(t *testing.T) {
+	for _, tt := range yearTests {
+		y := tt.year
+		if y.IsLeap() != tt.leap || y.HasAldrinDay() != tt.leap {
+			t.Errorf("Year %d: IsLeap %v, HasAldrinDay %v; expected %v", y, y.IsLeap(), y.HasAldrinDay(), tt.leap)
+		}
+		if y.HasArmstrongDay() != tt.armstrong {
+			t.Errorf("Year %d: HasArmstrongDay %v; expected %v", y, y.HasArmstrongDay(), tt.armstrong)
+		}
+		if y.Len() != tt.length {
+			t.Errorf("Year %d: Len %d; expected %d", y, y.Len(), tt.length)
+		}
+	}
+}
+
+func TestYearBoundsMatchConversion(t *testing.T) {
+	for y := TqYear(-300); y <= 300; y++ {
+		sy, syd, err := y.GregorianStart()
+		if err != nil {
+			t.Fatalf("Year %d: GregorianStart returned %v", y, err)
+		}
+		ey, eyd, err := y.GregorianEnd()
+		if err != nil {
+			t.Fatalf("Year %d: GregorianEnd returned %v", y, err)
+		}
+		if n := int(gDaysBeforeYear(ey)-gDaysBeforeYear(sy)) + eyd - syd + 1; n != y.Len() {
+			t.Errorf("Year %d spans %d Gregorian days; Len is %d", y, n, y.Len())
+		}
+		before, _ := FromGregorian(sy, int64(syd)-1)
+		after, _ := FromGregorian(ey, int64(eyd)+1)
+		if TqYear(before.Year) == y || TqYear(after.Year) == y {
+			t.Errorf("Year %d: days outside GregorianStart and GregorianEnd belong to the year", y)
+		}
+		aldrin := false
+		for d := syd; d < syd+y.Len(); d++ {
+			tqd, _ := FromGregorian(sy, int64(d))
+			aldrin = aldrin || tqd.Day == AldrinDay
+		}
+		if aldrin != y.HasAldrinDay() {
+			t.Errorf("Year %d: contains Aldrin Day %v; HasAldrinDay %v", y, aldrin, y.HasAldrinDay())
+		}
+	}
+}
+
+func TestYearMonths(t *testing.T) {
+	if len(TqYear(0).Months()) != 0 {
+		t.Error("Year 0 has months.")
+	}
+	months := TqYear(55).Months()
+	if len(months) != int(Mendel) || months[0] != Archimedes || months[len(months)-1] != Mendel {
+		t.Errorf("Months of 55 AT: %v", months)
+	}
+}
+
+func TestYearOutOfRange(t *testing.T) {
+	if _, _, err := TqYear(MaxYear + 1).GregorianStart(); err != ErrOutOfRange {
+		t.Errorf("GregorianStart after MaxYear returned %v", err)
+	}
+	if _, _, err := TqYear(MinYear - 1).GregorianEnd(); err != ErrOutOfRange {
+		t.Errorf("GregorianEnd before MinYear returned %v", err)
+	}
+}